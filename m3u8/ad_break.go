@@ -0,0 +1,660 @@
+package m3u8
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/scte35"
+)
+
+// adBreakBoundaryTolerance is the maximum distance between a marker date or duration and a segment boundary,
+// origins often write the dates of #EXT-X-DATERANGE with a precision of a second
+const adBreakBoundaryTolerance = 500 * time.Millisecond
+
+// cueOutContValue is the CUE-OUT value of the #EXT-X-SCTE35 repeating the start of a break
+const cueOutContValue = "CONT"
+
+// AdBreak represents an ad break of a media playlist, delimited by the markers starting and ending it
+type AdBreak struct {
+	// ID is the ID of the start marker (ID attribute of #EXT-X-SCTE35 or #EXT-X-DATERANGE), empty for cue tags
+	ID string
+	// StartSegment is the index (in Segments) of the first segment of the break
+	StartSegment int
+	// EndSegment is the index of the first segment following the break:
+	// StartSegment for a break without segments, the number of segments for a break not ended
+	// and continuing after the playlist (or without planned duration)
+	EndSegment int
+	// PlannedDuration is the duration announced by the markers, nil when unknown
+	PlannedDuration *float64
+	// Duration is the duration of the segments of the break
+	Duration float64
+	// Elapsed is the time of the break before its first segment, for a break started before the playlist
+	Elapsed float64
+	UPID    *scte35.UPID
+	// Markers holds the items signaling the break (#EXT-X-SCTE35, #EXT-X-DATERANGE and cue tags)
+	Markers []Item
+}
+
+// markerKind represents the role of an ad break marker
+type markerKind int
+
+const (
+	outMarker markerKind = iota
+	contMarker
+	inMarker
+)
+
+// adMarker represents an item signaling the start, the continuation or the end of an ad break
+type adMarker struct {
+	item Item
+	kind markerKind
+	// key pairs the markers of a break: the kind of tag and the ID
+	key     string
+	id      string
+	segment int
+	planned *float64
+	elapsed float64
+	upid    *scte35.UPID
+}
+
+// adBreakState holds an ad break while pairing markers
+type adBreakState struct {
+	*AdBreak
+	ended bool
+	// unmatched is set when the break is ended by the start of the next one
+	unmatched bool
+	// dateRange is the #EXT-X-DATERANGE starting the break, which may end it with its duration
+	dateRange *DateRangeItem
+}
+
+// AdBreaks returns the ad breaks of a media playlist, pairing the markers starting and ending them:
+// #EXT-X-SCTE35 (CUE-OUT/CUE-IN or the segmentation type), #EXT-X-DATERANGE (SCTE35-OUT/SCTE35-IN, END-ON-NEXT,
+// DURATION or END-DATE) and the #EXT-X-CUE-OUT/#EXT-X-CUE-OUT-CONT/#EXT-X-CUE-IN tags.
+// A break signaled by several kinds of markers is returned once, a break without end marker lasts its planned duration,
+// or continues after the playlist when it's unknown and the playlist is live.
+// Unmatched markers, overlapping breaks and breaks not on segment boundaries or not lasting their planned duration
+// are returned as errors (see ErrAdBreakUnmatched, ErrAdBreakOverlap and ErrAdBreakBoundary).
+func (pl *Playlist) AdBreaks() ([]*AdBreak, []error) {
+	if pl.IsMaster() {
+		return nil, nil
+	}
+
+	segments := pl.Segments()
+	offsets := make([]float64, len(segments)+1)
+	for i, s := range segments {
+		offsets[i+1] = offsets[i] + s.Duration
+	}
+	times := pl.ProgramDateTimes()
+
+	var (
+		states  []*adBreakState
+		errs    []error
+		orphans []adMarker
+		segment int
+	)
+	open := make(map[string]*adBreakState)
+	endOnNext := make(map[string]*adBreakState)
+
+	end := func(b *adBreakState, segment int) {
+		b.EndSegment = segment
+		b.ended = true
+		for key, state := range open {
+			if state == b {
+				delete(open, key)
+			}
+		}
+		for class, state := range endOnNext {
+			if state == b {
+				delete(endOnNext, class)
+			}
+		}
+	}
+
+	for _, item := range pl.Items {
+		if _, ok := item.(*SegmentItem); ok {
+			segment++
+			continue
+		}
+		dri, isDateRange := item.(*DateRangeItem)
+		if isDateRange && dri.Class != nil {
+			if b, ok := endOnNext[*dri.Class]; ok {
+				end(b, segment)
+			}
+		}
+
+		m, ok := newAdMarker(item, segment, open)
+		if !ok {
+			continue
+		}
+
+		b := open[m.key]
+		switch m.kind {
+		case outMarker:
+			if b != nil {
+				errs = append(errs, fmt.Errorf("%w: ad break %q starting at segment %d is not ended", ErrAdBreakUnmatched, b.ID, b.StartSegment))
+				b.unmatched = true
+				end(b, segment)
+			}
+			b = &adBreakState{AdBreak: &AdBreak{ID: m.id, StartSegment: segment}}
+			if isDateRange {
+				b.dateRange = dri
+				if dri.EndOnNext && dri.Class != nil {
+					endOnNext[*dri.Class] = b
+				}
+				errs = append(errs, checkAdBreakBoundary(b.ID, "starts", dri.StartDate, segment, times)...)
+			}
+			open[m.key] = b
+			states = append(states, b)
+		case contMarker:
+			if b == nil {
+				if segment > 0 {
+					errs = append(errs, fmt.Errorf("%w: ad break %q continued at segment %d is not started", ErrAdBreakUnmatched, m.id, segment))
+				}
+				b = &adBreakState{AdBreak: &AdBreak{ID: m.id, StartSegment: segment, Elapsed: m.elapsed}}
+				open[m.key] = b
+				states = append(states, b)
+			}
+		case inMarker:
+			if b == nil {
+				orphans = append(orphans, m)
+				continue
+			}
+			if isDateRange && dri.EndDate != nil {
+				errs = append(errs, checkAdBreakBoundary(b.ID, "ends", *dri.EndDate, segment, times)...)
+			}
+			end(b, segment)
+		}
+		b.addMarker(m)
+	}
+
+	// breaks not ended by a marker
+	for _, b := range states {
+		if b.ended {
+			continue
+		}
+		duration, ok := b.plannedRemaining()
+		if !ok {
+			// the live edge of a live playlist may be in the break, a VOD playlist must end it
+			if !pl.IsLive() {
+				errs = append(errs, fmt.Errorf("%w: ad break %q starting at segment %d is not ended", ErrAdBreakUnmatched, b.ID, b.StartSegment))
+			}
+			b.EndSegment = len(segments)
+			continue
+		}
+		target := offsets[b.StartSegment] + duration
+		b.EndSegment = sort.SearchFloat64s(offsets, target-adBreakBoundaryTolerance.Seconds())
+		switch {
+		case b.EndSegment >= len(offsets):
+			// the break continues after the playlist
+			b.EndSegment = len(segments)
+		case math.Abs(offsets[b.EndSegment]-target) > adBreakBoundaryTolerance.Seconds():
+			errs = append(errs, fmt.Errorf("%w: ad break %q ends %.3fs before segment %d",
+				ErrAdBreakBoundary, b.ID, offsets[b.EndSegment]-target, b.EndSegment))
+		}
+	}
+
+	breaks := make([]*AdBreak, 0, len(states))
+	endedByMarker := make(map[*AdBreak]bool)
+	for _, b := range states {
+		breaks = append(breaks, b.AdBreak)
+		endedByMarker[b.AdBreak] = b.ended && !b.unmatched
+	}
+	sort.SliceStable(breaks, func(i, j int) bool {
+		return breaks[i].StartSegment < breaks[j].StartSegment
+	})
+	breaks = mergeAdBreaks(breaks)
+
+	// end markers of a break started by another kind of marker
+	for _, m := range orphans {
+		var match *AdBreak
+		for _, b := range breaks {
+			if b.EndSegment == m.segment {
+				match = b
+				break
+			}
+		}
+		if match == nil {
+			errs = append(errs, fmt.Errorf("%w: ad break %q ended at segment %d is not started", ErrAdBreakUnmatched, m.id, m.segment))
+			continue
+		}
+		match.addMarker(m)
+	}
+
+	maxEnd := -1
+	var previous *AdBreak
+	for _, b := range breaks {
+		b.Duration = offsets[b.EndSegment] - offsets[b.StartSegment]
+		// breaks ended by their planned duration are checked above, the others may continue after the playlist
+		if endedByMarker[b] && b.PlannedDuration != nil &&
+			math.Abs(b.Elapsed+b.Duration-*b.PlannedDuration) > adBreakBoundaryTolerance.Seconds() {
+			errs = append(errs, fmt.Errorf("%w: ad break %q lasts %.3fs, planned %.3fs",
+				ErrAdBreakBoundary, b.ID, b.Elapsed+b.Duration, *b.PlannedDuration))
+		}
+		if previous != nil && b.StartSegment < maxEnd {
+			errs = append(errs, fmt.Errorf("%w: %q (segments %d-%d) and %q (segments %d-%d)", ErrAdBreakOverlap,
+				previous.ID, previous.StartSegment, previous.EndSegment, b.ID, b.StartSegment, b.EndSegment))
+		}
+		if b.EndSegment > maxEnd {
+			maxEnd = b.EndSegment
+			previous = b
+		}
+	}
+
+	return breaks, errs
+}
+
+// addMarker adds a marker to the break, setting the planned duration and the UPID when not known yet
+func (b *AdBreak) addMarker(m adMarker) {
+	b.Markers = append(b.Markers, m.item)
+	if b.PlannedDuration == nil {
+		b.PlannedDuration = m.planned
+	}
+	if b.UPID == nil {
+		b.UPID = m.upid
+	}
+}
+
+// plannedRemaining returns the duration of the break from its first segment when known:
+// the duration of the #EXT-X-DATERANGE starting it, or the planned duration less the time elapsed
+func (b *adBreakState) plannedRemaining() (float64, bool) {
+	if duration, ok := b.dateRangeDuration(); ok {
+		return duration, true
+	}
+	if b.PlannedDuration == nil {
+		return 0, false
+	}
+
+	return *b.PlannedDuration - b.Elapsed, true
+}
+
+// dateRangeDuration returns the duration of the #EXT-X-DATERANGE starting the break
+func (b *adBreakState) dateRangeDuration() (float64, bool) {
+	if b.dateRange == nil {
+		return 0, false
+	}
+	if b.dateRange.Duration != nil {
+		return *b.dateRange.Duration, true
+	}
+	if b.dateRange.EndDate == nil {
+		return 0, false
+	}
+	start, err := ParseTime(b.dateRange.StartDate)
+	if err != nil {
+		return 0, false
+	}
+	end, err := ParseTime(*b.dateRange.EndDate)
+	if err != nil {
+		return 0, false
+	}
+
+	return end.Sub(start).Seconds(), true
+}
+
+// mergeAdBreaks merges the breaks of the same segments, signaled by several kinds of markers
+func mergeAdBreaks(breaks []*AdBreak) []*AdBreak {
+	var result []*AdBreak
+	for _, b := range breaks {
+		var same *AdBreak
+		for _, r := range result {
+			if r.StartSegment == b.StartSegment && r.EndSegment == b.EndSegment && r.StartSegment != r.EndSegment {
+				same = r
+				break
+			}
+		}
+		if same == nil {
+			result = append(result, b)
+			continue
+		}
+		same.Markers = append(same.Markers, b.Markers...)
+		if same.PlannedDuration == nil {
+			same.PlannedDuration = b.PlannedDuration
+		}
+		if same.UPID == nil {
+			same.UPID = b.UPID
+		}
+		if same.Elapsed == 0 {
+			same.Elapsed = b.Elapsed
+		}
+	}
+
+	return result
+}
+
+// checkAdBreakBoundary returns an error when the date of a marker is not the date of the segment following it
+func checkAdBreakBoundary(id, verb, date string, segment int, times []*time.Time) []error {
+	if segment >= len(times) || times[segment] == nil {
+		return nil
+	}
+	t, err := ParseTime(date)
+	if err != nil {
+		return nil
+	}
+	if d := t.Sub(*times[segment]); d > adBreakBoundaryTolerance || d < -adBreakBoundaryTolerance {
+		return []error{fmt.Errorf("%w: ad break %q %s %s from segment %d", ErrAdBreakBoundary, id, verb, d, segment)}
+	}
+
+	return nil
+}
+
+// newAdMarker returns the ad break marker of an item, open holds the breaks not ended yet
+func newAdMarker(item Item, segment int, open map[string]*adBreakState) (adMarker, bool) {
+	m := adMarker{item: item, segment: segment}
+
+	switch it := item.(type) {
+	case *SCTE35Item:
+		m.key = SCTE35Tag
+		if it.ID != nil {
+			m.id = *it.ID
+			m.key += ":" + m.id
+		}
+		switch {
+		case it.CueIn != nil && *it.CueIn == parser.YesValue:
+			m.kind = inMarker
+		case it.CueOut != nil && *it.CueOut == cueOutContValue:
+			m.kind = contMarker
+		case it.CueOut != nil && *it.CueOut == parser.YesValue:
+			m.kind = outMarker
+		default:
+			st := it.SegmentationType()
+			switch {
+			case st == nil:
+				return m, false
+			case adBreakStartTypes[*st] && it.Elapsed != nil && *it.Elapsed > 0:
+				m.kind = contMarker
+			case adBreakStartTypes[*st]:
+				m.kind = outMarker
+			case adBreakEndTypes[*st]:
+				m.kind = inMarker
+			default:
+				return m, false
+			}
+		}
+		m.planned = it.Duration
+		if it.Elapsed != nil {
+			m.elapsed = *it.Elapsed
+		}
+		if upid, err := it.TypedUPID(); err == nil && upid != nil {
+			m.upid = upid
+		} else if section, err := it.SpliceInfo(); err == nil {
+			m.upid = sectionUPID(section)
+		}
+	case *DateRangeItem:
+		m.key = DateRangeItemTag + ":" + it.ID
+		m.id = it.ID
+		var section *scte35.SpliceInfoSection
+		switch {
+		case it.Scte35Out != nil:
+			m.kind = outMarker
+			section, _ = it.Scte35OutInfo()
+		case it.Scte35In != nil:
+			m.kind = inMarker
+			section, _ = it.Scte35InInfo()
+		case it.EndOnNext:
+			m.kind = outMarker
+		case open[m.key] != nil && (it.EndDate != nil || it.Duration != nil):
+			m.kind = inMarker
+		default:
+			return m, false
+		}
+		m.planned = it.PlannedDuration
+		if m.planned == nil && m.kind == outMarker {
+			m.planned = it.Duration
+		}
+		if section != nil {
+			m.upid = sectionUPID(section)
+			if m.planned == nil {
+				m.planned = sectionDuration(section)
+			}
+		}
+	case *UnknownItem:
+		m.key = "CUE"
+		text := it.String()
+		value := strings.TrimSpace(strings.TrimPrefix(text, parser.ParseTagName(text)+":"))
+		switch parser.ParseTagName(text) {
+		case CueOutTag:
+			m.kind = outMarker
+			if d, err := strconv.ParseFloat(strings.TrimPrefix(value, "DURATION="), 64); err == nil {
+				m.planned = &d
+			}
+		case CueOutContTag:
+			m.kind = contMarker
+			// ElapsedTime=10,Duration=30 or 10/30
+			elapsed, duration := value, ""
+			if i := strings.Index(value, "/"); i >= 0 {
+				elapsed, duration = value[:i], value[i+1:]
+			}
+			attributes := parser.ParseAttributes(value)
+			if v, ok := attributes["ElapsedTime"]; ok {
+				elapsed = v
+			}
+			if v, ok := attributes["Duration"]; ok {
+				duration = v
+			}
+			m.elapsed, _ = strconv.ParseFloat(elapsed, 64)
+			if d, err := strconv.ParseFloat(duration, 64); err == nil {
+				m.planned = &d
+			}
+		case CueInTag:
+			m.kind = inMarker
+		default:
+			return m, false
+		}
+	default:
+		return m, false
+	}
+
+	return m, true
+}
+
+// adBreakStartTypes and adBreakEndTypes are the segmentation types of the markers of ad breaks
+var (
+	adBreakStartTypes = map[scte35.SegmentationType]bool{
+		scte35.BreakStart:                           true,
+		scte35.ProviderAdStart:                      true,
+		scte35.DistributorAdStart:                   true,
+		scte35.ProviderPlacementOpportunityStart:    true,
+		scte35.DistributorPlacementOpportunityStart: true,
+		scte35.ProviderAdBlockStart:                 true,
+		scte35.DistributorAdBlockStart:              true,
+	}
+	adBreakEndTypes = map[scte35.SegmentationType]bool{
+		scte35.BreakEnd:                           true,
+		scte35.ProviderAdEnd:                      true,
+		scte35.DistributorAdEnd:                   true,
+		scte35.ProviderPlacementOpportunityEnd:    true,
+		scte35.DistributorPlacementOpportunityEnd: true,
+		scte35.ProviderAdBlockEnd:                 true,
+		scte35.DistributorAdBlockEnd:              true,
+	}
+)
+
+// sectionUPID returns the UPID of the first segmentation descriptor of a section
+func sectionUPID(section *scte35.SpliceInfoSection) *scte35.UPID {
+	for _, d := range section.SegmentationDescriptors() {
+		return d.SegmentationUPID()
+	}
+
+	return nil
+}
+
+// sectionDuration returns the segmentation duration or the break duration of a section in seconds
+func sectionDuration(section *scte35.SpliceInfoSection) *float64 {
+	for _, d := range section.SegmentationDescriptors() {
+		if d.Duration != nil {
+			return d.DurationSeconds()
+		}
+	}
+	if si, ok := section.SpliceCommand.(*scte35.SpliceInsert); ok && si.BreakDuration != nil {
+		d := scte35.Seconds(si.BreakDuration.Duration)
+		return &d
+	}
+
+	return nil
+}
+
+// StripAdBreaks returns a copy of a media playlist without its ad breaks (see AdBreaks):
+// the segments of the breaks are removed along with their markers and date and time,
+// a single #EXT-X-DISCONTINUITY replaces the ones of a break (it's added when the break has none),
+// and the segment following a break gets its date and time, its keys and its map when known.
+// The copy starts with the media and discontinuity sequence numbers of its first segment.
+// It fails with the ErrAdBreakUnmatched error of AdBreaks when a marker is unmatched,
+// as the end of the break (or its start) is not known then, except for a break at the live edge of a live playlist
+// which is removed up to the end of the playlist.
+func (pl *Playlist) StripAdBreaks() (*Playlist, error) {
+	if pl.IsMaster() {
+		return nil, ErrPlaylistInvalidType
+	}
+
+	breaks, errs := pl.AdBreaks()
+	for _, err := range errs {
+		if errors.Is(err, ErrAdBreakUnmatched) {
+			return nil, err
+		}
+	}
+	resolved := pl.ResolvedSegments()
+	times := pl.ProgramDateTimes()
+	removed := make([]bool, len(resolved))
+	markers := make(map[Item]bool)
+	for _, b := range breaks {
+		for i := b.StartSegment; i < b.EndSegment; i++ {
+			removed[i] = true
+		}
+		for _, m := range b.Markers {
+			markers[m] = true
+		}
+	}
+	first := -1
+	for i := range resolved {
+		if !removed[i] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil, ErrSegmentNotFound
+	}
+
+	var (
+		items []Item
+		// pending holds the tags preceding the next segment
+		pending []Item
+		index   int
+		// skipped is set when segments were removed since the last segment kept
+		skipped bool
+	)
+	for _, item := range pl.Items {
+		if markers[item] {
+			continue
+		}
+		si, ok := item.(*SegmentItem)
+		if !ok {
+			pending = append(pending, item)
+			continue
+		}
+
+		if removed[index] {
+			pending = removeSegmentTags(pending)
+			skipped = true
+			index++
+			continue
+		}
+		if skipped {
+			pending = resolveSegmentTags(removeSegmentTags(pending), resolved[index])
+			if index != first {
+				pending = append([]Item{&DiscontinuityItem{}}, pending...)
+			}
+			if si.ProgramDateTime == nil && times[index] != nil {
+				pending = append(pending, &TimeItem{Time: *times[index]})
+			}
+			// the start of a byte range may be implied by a removed segment
+			if rs := resolved[index]; si.ByteRange != nil && si.ByteRange.Start == nil && rs.ByteRange != nil {
+				clone := *si
+				clone.ByteRange = rs.ByteRange
+				si = &clone
+			}
+		}
+		items = append(append(items, pending...), si)
+		pending = nil
+		skipped = false
+		index++
+	}
+
+	head := resolved[first]
+	stripped := &Playlist{
+		Items:               append(items, pending...),
+		Version:             pl.Version,
+		Cache:               pl.Cache,
+		Target:              pl.Target,
+		Sequence:            head.MediaSequence,
+		Type:                pl.Type,
+		IFramesOnly:         pl.IFramesOnly,
+		IndependentSegments: pl.IndependentSegments,
+		Live:                pl.Live,
+		Master:              pl.Master,
+		PartInf:             pl.PartInf,
+		ServerControl:       pl.ServerControl,
+	}
+	if pl.DiscontinuitySequence != nil || head.DiscontinuitySequence != 0 {
+		sequence := head.DiscontinuitySequence
+		stripped.DiscontinuitySequence = &sequence
+	}
+
+	return stripped, nil
+}
+
+// removeSegmentTags removes the #EXT-X-DISCONTINUITY and #EXT-X-PROGRAM-DATE-TIME tags from the tags of a segment
+func removeSegmentTags(items []Item) []Item {
+	var result []Item
+	for _, item := range items {
+		switch item.(type) {
+		case *DiscontinuityItem, *TimeItem:
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// resolveSegmentTags replaces the #EXT-X-KEY and #EXT-X-MAP tags of removed segments and of the segment following them
+// by the keys and the map in effect for this segment
+func resolveSegmentTags(items []Item, rs *ResolvedSegment) []Item {
+	var (
+		result  []Item
+		lastKey *KeyItem
+		hasMap  bool
+	)
+	for _, item := range items {
+		switch it := item.(type) {
+		case *KeyItem:
+			lastKey = it
+			continue
+		case *MapItem:
+			hasMap = true
+			continue
+		}
+		result = append(result, item)
+	}
+
+	switch {
+	case lastKey == nil:
+	case len(rs.Keys) == 0:
+		// METHOD=NONE
+		result = append(result, lastKey)
+	default:
+		for _, key := range rs.Keys {
+			result = append(result, key)
+		}
+	}
+	if hasMap && rs.Map != nil {
+		result = append(result, rs.Map)
+	}
+
+	return result
+}
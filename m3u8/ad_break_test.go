@@ -0,0 +1,505 @@
+package m3u8
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/scte35"
+)
+
+func adBreakPlaylist(lines ...string) string {
+	return strings.Join(append([]string{
+		"#EXTM3U",
+		"#EXT-X-VERSION:6",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:00Z",
+	}, lines...), "\n")
+}
+
+func assertAdBreakErrors(t *testing.T, errs []error, targets ...error) {
+	require.Len(t, errs, len(targets), errs)
+	for i, target := range targets {
+		assert.True(t, errors.Is(errs[i], target), errs[i])
+	}
+}
+
+func TestPlaylist_AdBreaks_SCTE35(t *testing.T) {
+	pl, err := ReadFile("fixtures/fer_with_ads.m3u8")
+	require.NoError(t, err)
+
+	// the markers are written after the ad segments, so the breaks have no segments
+	// and don't last their planned duration
+	breaks, errs := pl.AdBreaks()
+	require.Len(t, breaks, 24)
+	require.Len(t, errs, 24)
+	for _, err := range errs {
+		assert.True(t, errors.Is(err, ErrAdBreakBoundary), err)
+	}
+	assert.EqualError(t, errs[0], `ad break not on segment boundary: ad break "5" lasts 0.000s, planned 120.114s`)
+
+	b := breaks[0]
+	assert.Equal(t, "5", b.ID)
+	assert.Equal(t, 142, b.StartSegment)
+	assert.Equal(t, 142, b.EndSegment)
+	assert.Equal(t, 0.0, b.Duration)
+	assertNotNilEqual(t, 120.114, b.PlannedDuration)
+	require.NotNil(t, b.UPID)
+	assert.Equal(t, "NBCU", b.UPID.FormatIdentifier())
+	require.Len(t, b.Markers, 2)
+	assert.IsType(t, &SCTE35Item{}, b.Markers[0])
+	assert.IsType(t, &SCTE35Item{}, b.Markers[1])
+
+	assert.Equal(t, 1946, breaks[16].StartSegment)
+	assertNotNilEqual(t, 165.059, breaks[16].PlannedDuration)
+}
+
+func TestPlaylist_AdBreaks_DateRangeAndSCTE35(t *testing.T) {
+	pl, err := ReadFile("fixtures/sle_drm.m3u8")
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 10)
+
+	// the playlist starts in a break: CUE-OUT=CONT, ended by a #EXT-X-SCTE35 and a #EXT-X-DATERANGE
+	b := breaks[0]
+	assert.Equal(t, "380", b.ID)
+	assert.Equal(t, 0, b.StartSegment)
+	assert.Equal(t, 27, b.EndSegment)
+	assert.InDelta(t, 15.482, b.Elapsed, 0.0005)
+	assert.InDelta(t, 104.571, b.Duration, 0.0005)
+	assert.IsType(t, &DateRangeItem{}, b.Markers[len(b.Markers)-1])
+
+	// #EXT-X-DATERANGE and #EXT-X-SCTE35 (repeated with CUE-OUT=CONT) signaling the same break
+	b = breaks[1]
+	assert.Equal(t, "0x30-381-1689096291", b.ID)
+	assert.Equal(t, 192, b.StartSegment)
+	assert.Equal(t, 223, b.EndSegment)
+	assert.Zero(t, b.Elapsed)
+	assert.InDelta(t, 120.054, b.Duration, 0.0005)
+	assertNotNilEqual(t, 120.02, b.PlannedDuration)
+	require.NotNil(t, b.UPID)
+	assert.Equal(t, "NBCU", b.UPID.FormatIdentifier())
+	assert.Len(t, b.Markers, 34)
+
+	// server-side inserted ads, only ended by a #EXT-X-SCTE35
+	b = breaks[7]
+	assert.Equal(t, "0x30-387-1689100881", b.ID)
+	assert.Equal(t, 1368, b.StartSegment)
+	assert.Equal(t, 1400, b.EndSegment)
+	assert.Len(t, b.Markers, 3)
+}
+
+func TestPlaylist_AdBreaks_CueOutContPlannedDuration(t *testing.T) {
+	for _, cont := range []string{"#EXT-X-CUE-OUT-CONT:ElapsedTime=10,Duration=30", "#EXT-X-CUE-OUT-CONT:10/30"} {
+		// the playlist starts in a break without end marker, which ends after its planned duration
+		pl, err := ReadString(adBreakPlaylist(
+			cont,
+			"#EXTINF:10,", "ad1.ts",
+			"#EXTINF:10,", "ad2.ts",
+			"#EXTINF:10,", "s0.ts",
+		))
+		require.NoError(t, err)
+
+		breaks, errs := pl.AdBreaks()
+		assert.Empty(t, errs, cont)
+		require.Len(t, breaks, 1, cont)
+		assertNotNilEqual(t, 30.0, breaks[0].PlannedDuration)
+		assert.Equal(t, 10.0, breaks[0].Elapsed, cont)
+		assert.Equal(t, 0, breaks[0].StartSegment, cont)
+		assert.Equal(t, 2, breaks[0].EndSegment, cont)
+	}
+}
+
+func TestPlaylist_AdBreaks_DateRangeDuration(t *testing.T) {
+	pl, err := ReadFile("fixtures/dateRangeScte35.m3u8")
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, "splice-6FFFFFF0", breaks[0].ID)
+	assert.Equal(t, 0, breaks[0].StartSegment)
+	assert.Equal(t, 3, breaks[0].EndSegment)
+	assertNotNilEqual(t, 59.993, breaks[0].PlannedDuration)
+	assert.Len(t, breaks[0].Markers, 2)
+}
+
+func TestPlaylist_AdBreaks_EndOnNext(t *testing.T) {
+	pl, err := ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		`#EXT-X-DATERANGE:ID="ad1",CLASS="ad",START-DATE="2020-01-01T00:00:10Z",END-ON-NEXT=YES`,
+		"#EXTINF:10,", "ad0.ts",
+		"#EXTINF:10,", "ad1.ts",
+		`#EXT-X-DATERANGE:ID="ad2",CLASS="ad",START-DATE="2020-01-01T00:00:30Z",END-ON-NEXT=YES`,
+		"#EXTINF:10,", "ad2.ts",
+		`#EXT-X-DATERANGE:ID="other",CLASS="other",START-DATE="2020-01-01T00:00:40Z"`,
+		"#EXTINF:10,", "s1.ts",
+		`#EXT-X-DATERANGE:ID="end",CLASS="ad",START-DATE="2020-01-01T00:00:50Z"`,
+		"#EXTINF:10,", "s2.ts",
+	))
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 2)
+	assert.Equal(t, "ad1", breaks[0].ID)
+	assert.Equal(t, 1, breaks[0].StartSegment)
+	assert.Equal(t, 3, breaks[0].EndSegment)
+	assert.Equal(t, 20.0, breaks[0].Duration)
+	assert.Equal(t, "ad2", breaks[1].ID)
+	assert.Equal(t, 3, breaks[1].StartSegment)
+	assert.Equal(t, 5, breaks[1].EndSegment)
+}
+
+func TestPlaylist_AdBreaks_CueTags(t *testing.T) {
+	pl, err := ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-OUT:DURATION=30",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXT-X-CUE-OUT-CONT:ElapsedTime=10,Duration=30",
+		"#EXTINF:10,", "ad1.ts",
+		"#EXT-X-CUE-OUT-CONT:20/30",
+		"#EXTINF:10,", "ad2.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,", "s1.ts",
+	))
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 1, breaks[0].StartSegment)
+	assert.Equal(t, 4, breaks[0].EndSegment)
+	assert.Equal(t, 30.0, breaks[0].Duration)
+	assertNotNilEqual(t, 30.0, breaks[0].PlannedDuration)
+	assert.Len(t, breaks[0].Markers, 4)
+
+	// joined during the break
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXT-X-CUE-OUT-CONT:20/30",
+		"#EXTINF:10,", "ad2.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,", "s1.ts",
+	))
+	require.NoError(t, err)
+
+	breaks, errs = pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 0, breaks[0].StartSegment)
+	assert.Equal(t, 1, breaks[0].EndSegment)
+	assert.Equal(t, 20.0, breaks[0].Elapsed)
+}
+
+func TestPlaylist_AdBreaks_Unmatched(t *testing.T) {
+	pl, err := ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,", "s1.ts",
+		"#EXT-X-CUE-OUT:30",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXT-X-CUE-OUT:30",
+		"#EXTINF:10,", "ad1.ts",
+	))
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	// the last break may continue after the live playlist for its planned duration
+	assertAdBreakErrors(t, errs, ErrAdBreakUnmatched, ErrAdBreakUnmatched)
+	require.Len(t, breaks, 2)
+	assert.Equal(t, 2, breaks[0].StartSegment)
+	assert.Equal(t, 3, breaks[0].EndSegment)
+	assert.Equal(t, 3, breaks[1].StartSegment)
+	assert.Equal(t, 4, breaks[1].EndSegment)
+
+	// a break not ended lasts its planned duration
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXT-X-PLAYLIST-TYPE:VOD",
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-OUT:10",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXTINF:10,", "s1.ts",
+		"#EXTINF:10,", "s2.ts",
+		"#EXT-X-ENDLIST",
+	))
+	require.NoError(t, err)
+
+	breaks, errs = pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 1, breaks[0].StartSegment)
+	assert.Equal(t, 2, breaks[0].EndSegment)
+
+	// the ones without planned duration last until the end of the playlist
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-OUT",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXTINF:10,", "s1.ts",
+		"#EXT-X-ENDLIST",
+	))
+	require.NoError(t, err)
+
+	breaks, errs = pl.AdBreaks()
+	assertAdBreakErrors(t, errs, ErrAdBreakUnmatched)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 3, breaks[0].EndSegment)
+}
+
+func TestPlaylist_AdBreaks_PlannedDuration(t *testing.T) {
+	pl, err := ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-OUT:30",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,", "s1.ts",
+		"#EXT-X-ENDLIST",
+	))
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assertAdBreakErrors(t, errs, ErrAdBreakBoundary)
+	assert.EqualError(t, errs[0], `ad break not on segment boundary: ad break "" lasts 10.000s, planned 30.000s`)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 10.0, breaks[0].Duration)
+}
+
+func TestPlaylist_AdBreaks_Overlap(t *testing.T) {
+	pl, err := ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		`#EXT-X-DATERANGE:ID="a",START-DATE="2020-01-01T00:00:10Z",DURATION=20,SCTE35-OUT=0xFC`,
+		"#EXTINF:10,", "ad0.ts",
+		`#EXT-X-DATERANGE:ID="b",START-DATE="2020-01-01T00:00:20Z",DURATION=20,SCTE35-OUT=0xFC`,
+		"#EXTINF:10,", "ad1.ts",
+		"#EXTINF:10,", "ad2.ts",
+		"#EXTINF:10,", "s1.ts",
+	))
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assertAdBreakErrors(t, errs, ErrAdBreakOverlap)
+	require.Len(t, breaks, 2)
+	assert.Equal(t, 3, breaks[0].EndSegment)
+	assert.Equal(t, 2, breaks[1].StartSegment)
+	assert.Equal(t, 4, breaks[1].EndSegment)
+}
+
+func TestPlaylist_AdBreaks_Boundary(t *testing.T) {
+	pl, err := ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		`#EXT-X-DATERANGE:ID="a",START-DATE="2020-01-01T00:00:12Z",DURATION=15,SCTE35-OUT=0xFC`,
+		"#EXTINF:10,", "ad0.ts",
+		"#EXTINF:10,", "ad1.ts",
+		"#EXTINF:10,", "s1.ts",
+	))
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assertAdBreakErrors(t, errs, ErrAdBreakBoundary, ErrAdBreakBoundary)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 1, breaks[0].StartSegment)
+	assert.Equal(t, 3, breaks[0].EndSegment)
+}
+
+func TestPlaylist_AdBreaks_Master(t *testing.T) {
+	pl, err := ReadFile("fixtures/master.m3u8")
+	require.NoError(t, err)
+
+	breaks, errs := pl.AdBreaks()
+	assert.Nil(t, breaks)
+	assert.Nil(t, errs)
+}
+
+func TestPlaylist_StripAdBreaks(t *testing.T) {
+	pl, err := ReadString(strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-VERSION:6",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-MEDIA-SEQUENCE:100",
+		"#EXT-X-DISCONTINUITY-SEQUENCE:5",
+		"#EXT-X-DISCONTINUITY",
+		"#EXT-X-CUE-OUT-CONT:10/20",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:00Z",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100@0",
+		"main.ts",
+		"#EXT-X-CUE-IN",
+		`#EXT-X-KEY:METHOD=AES-128,URI="https://example.com/key"`,
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100",
+		"main.ts",
+		`#EXT-X-DATERANGE:ID="ad",START-DATE="2020-01-01T00:00:20Z",DURATION=20,SCTE35-OUT=0xFC`,
+		"#EXT-X-DISCONTINUITY",
+		"#EXTINF:10,",
+		"ad0.ts",
+		"#EXTINF:10,",
+		"ad1.ts",
+		"#EXT-X-DISCONTINUITY",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:40Z",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100@200",
+		"main.ts",
+		"#EXT-X-CUE-OUT:10",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100",
+		"main.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100",
+		"main.ts",
+		"#EXT-X-ENDLIST",
+	}, "\n"))
+	require.NoError(t, err)
+
+	stripped, err := pl.StripAdBreaks()
+	require.NoError(t, err)
+
+	out, err := Write(stripped)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-VERSION:6",
+		"#EXT-X-MEDIA-SEQUENCE:101",
+		"#EXT-X-DISCONTINUITY-SEQUENCE:6",
+		"#EXT-X-TARGETDURATION:10",
+		`#EXT-X-KEY:METHOD=AES-128,URI="https://example.com/key"`,
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:10Z",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100@100",
+		"main.ts",
+		"#EXT-X-DISCONTINUITY",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:40Z",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100@200",
+		"main.ts",
+		"#EXT-X-DISCONTINUITY",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:01:00Z",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:100@400",
+		"main.ts",
+		"#EXT-X-ENDLIST",
+		"",
+	}, "\n"), out)
+
+	// the source is not modified
+	assert.Len(t, pl.Segments(), 7)
+}
+
+func TestPlaylist_StripAdBreaks_Fixtures(t *testing.T) {
+	pl, err := ReadFile("fixtures/sle_drm.m3u8")
+	require.NoError(t, err)
+
+	stripped, err := pl.StripAdBreaks()
+	require.NoError(t, err)
+	assert.Len(t, stripped.Segments(), 1850-309)
+	assert.Equal(t, 8534+27, stripped.Sequence)
+	assertNotNilEqual(t, 0, stripped.DiscontinuitySequence)
+	breaks, errs := stripped.AdBreaks()
+	assert.Empty(t, breaks)
+	assert.Empty(t, errs)
+
+	// the segments keep their date and time
+	times := stripped.ProgramDateTimes()
+	for i, rs := range stripped.ResolvedSegments() {
+		require.NotNil(t, times[i])
+		if rs.Discontinuity {
+			assert.NotNil(t, rs.ProgramDateTime, i)
+		}
+	}
+
+	// the ad pods of fer_with_ads.m3u8 precede the #EXT-X-SCTE35 markers, which delimit empty breaks:
+	// only the markers of the ads are removed
+	pl, err = ReadFile("fixtures/fer_with_ads.m3u8")
+	require.NoError(t, err)
+
+	stripped, err = pl.StripAdBreaks()
+	require.NoError(t, err)
+	assert.Len(t, stripped.Segments(), len(pl.Segments()))
+	assert.Len(t, stripped.Items, len(pl.Items)-48)
+	for _, item := range stripped.Items {
+		if si, ok := item.(*SCTE35Item); ok {
+			st := si.SegmentationType()
+			require.NotNil(t, st)
+			assert.True(t, *st == scte35.ProgramStart || *st == scte35.ProgramEnd, si.String())
+		}
+	}
+
+	// vod_without_ads.m3u8 is vod_with_ads.m3u8 without its ad pods, signaled by cue tags and a #EXT-X-DATERANGE
+	pl, err = ReadFile("fixtures/vod_with_ads.m3u8")
+	require.NoError(t, err)
+	breaks, errs = pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 2)
+
+	stripped, err = pl.StripAdBreaks()
+	require.NoError(t, err)
+	out, err := Write(stripped)
+	require.NoError(t, err)
+
+	without, err := ReadFile("fixtures/vod_without_ads.m3u8")
+	require.NoError(t, err)
+	expected, err := Write(without)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestPlaylist_StripAdBreaks_Errors(t *testing.T) {
+	pl, err := ReadFile("fixtures/master.m3u8")
+	require.NoError(t, err)
+	_, err = pl.StripAdBreaks()
+	assert.Equal(t, ErrPlaylistInvalidType, err)
+
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXT-X-CUE-OUT:10",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXT-X-CUE-IN",
+	))
+	require.NoError(t, err)
+	_, err = pl.StripAdBreaks()
+	assert.Equal(t, ErrSegmentNotFound, err)
+
+	// the end of a break without end marker nor planned duration is not known
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-OUT",
+		"#EXTINF:10,", "ad0.ts",
+		"#EXTINF:10,", "s1.ts",
+		"#EXT-X-ENDLIST",
+	))
+	require.NoError(t, err)
+	_, err = pl.StripAdBreaks()
+	assert.True(t, errors.Is(err, ErrAdBreakUnmatched), err)
+
+	// unless the live edge is in the break
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-OUT",
+		"#EXTINF:10,", "ad0.ts",
+	))
+	require.NoError(t, err)
+	breaks, errs := pl.AdBreaks()
+	assert.Empty(t, errs)
+	require.Len(t, breaks, 1)
+	assert.Equal(t, 1, breaks[0].StartSegment)
+	assert.Equal(t, 2, breaks[0].EndSegment)
+	stripped, err := pl.StripAdBreaks()
+	require.NoError(t, err)
+	require.Equal(t, 1, stripped.SegmentSize())
+	assert.Equal(t, "s0.ts", stripped.Segments()[0].Segment)
+	assert.True(t, stripped.IsLive())
+
+	// an end marker without start still fails
+	pl, err = ReadString(adBreakPlaylist(
+		"#EXTINF:10,", "s0.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,", "s1.ts",
+	))
+	require.NoError(t, err)
+	_, err = pl.StripAdBreaks()
+	assert.True(t, errors.Is(err, ErrAdBreakUnmatched), err)
+}
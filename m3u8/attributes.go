@@ -1,23 +1,28 @@
 package m3u8
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
 )
 
-// deleteKeys used on attributes map to delete all known attributes to leave only unknown
-func deleteKeys(m map[string]string, keys ...string) {
-	for _, key := range keys {
-		delete(m, key)
-	}
+// attributesJoin utility function to unfold attributes into a slice, in order
+func attributesJoin(slice []string, attributes parser.AttributeList) []string {
+	return append(slice, attributes.Format()...)
 }
 
-// attributesJoinMap utility function to unfold map's values into a slice
-func attributesJoinMap(attributes []string, attributesMap map[string]string) []string {
-	for attributeKey, attribute := range attributesMap {
-		attributes = append(attributes, fmt.Sprintf(parser.FormatString, attributeKey, attribute))
+// sortedAttributes returns the attributes of a map sorted by name, for a deterministic output
+func sortedAttributes(attributes map[string]string) parser.AttributeList {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var list parser.AttributeList
+	for _, name := range names {
+		list = append(list, parser.Attribute{Name: name, Value: attributes[name]})
 	}
 
-	return attributes
+	return list
 }
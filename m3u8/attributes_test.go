@@ -1,38 +1,27 @@
 package m3u8
 
 import (
-	"strings"
 	"testing"
 
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
 	"github.com/stretchr/testify/require"
 )
 
-func TestAttributesJoinMap(t *testing.T) {
+func TestAttributesJoin(t *testing.T) {
 	attrs := []string{"attr1=v1", "attr2=v2"}
-	attributesMap := map[string]string{
-		"k1": "v1",
-		"k2": "\"v1\"",
-		"k3": "v3",
-		"k4": "\"v4\"",
+	attributes := parser.AttributeList{
+		{Name: "k3", Value: "v3"},
+		{Name: "k1", Value: "v1"},
+		{Name: "k4", Value: "\"v4\""},
+		{Name: "k2", Value: "\"v1\""},
 	}
 
-	expectedAttrsMap := map[string]string{
-		"k1":    "v1",
-		"k2":    "\"v1\"",
-		"k3":    "v3",
-		"k4":    "\"v4\"",
-		"attr1": "v1",
-		"attr2": "v2",
-	}
-	gotAttrsMap := make(map[string]string, len(expectedAttrsMap))
-
-	attrs = attributesJoinMap(attrs, attributesMap)
-	for _, attribute := range attrs {
-		kv := strings.Split(attribute, "=")
-		require.Len(t, kv, 2)
-
-		gotAttrsMap[kv[0]] = kv[1]
-	}
+	attrs = attributesJoin(attrs, attributes)
+	require.Equal(t, []string{"attr1=v1", "attr2=v2", "k3=v3", "k1=v1", "k4=\"v4\"", "k2=\"v1\""}, attrs)
+}
 
-	require.Equal(t, expectedAttrsMap, gotAttrsMap)
+func TestSortedAttributes(t *testing.T) {
+	attributes := sortedAttributes(map[string]string{"k2": "v2", "k3": "v3", "k1": "v1"})
+	require.Equal(t, []string{"k1", "k2", "k3"}, attributes.Names())
+	require.Nil(t, sortedAttributes(nil))
 }
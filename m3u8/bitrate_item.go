@@ -0,0 +1,97 @@
+package m3u8
+
+import (
+	"fmt"
+	"math"
+)
+
+// BitrateTolerance is the maximum relative deviation of an actual segment bitrate
+// from the declared EXT-X-BITRATE value
+const BitrateTolerance = 0.1
+
+// BitrateItem represents a #EXT-X-BITRATE tag, the approximate bitrate (kbps)
+// of the segments which follow it until the next EXT-X-BITRATE
+type BitrateItem struct {
+	Bitrate int
+}
+
+// NewBitrateItem parses a text line and returns a *BitrateItem
+func NewBitrateItem(text string) (*BitrateItem, error) {
+	bitrate, err := parseIntValue(text, BitrateItemTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitrateItem{Bitrate: bitrate}, nil
+}
+
+func (bi *BitrateItem) String() string {
+	return fmt.Sprintf("%s:%d", BitrateItemTag, bi.Bitrate)
+}
+
+func (bi *BitrateItem) Validate() []error {
+	if bi.Bitrate <= 0 {
+		return []error{fmt.Errorf("%s value is not valid", BitrateItemTag)}
+	}
+
+	return nil
+}
+
+// SegmentBitrate represents the declared and actual bitrates (kbps) of a segment
+type SegmentBitrate struct {
+	Segment *SegmentItem
+	// Declared is the bitrate of the last EXT-X-BITRATE preceding the segment, if any
+	Declared *int
+	// Actual is computed from the byte range length and the duration of the segment, if known
+	Actual *float64
+}
+
+// Deviates checks if the actual bitrate differs from the declared one by more than tolerance
+// (a fraction of the declared bitrate), it's false when either of the bitrates is unknown
+func (sb SegmentBitrate) Deviates(tolerance float64) bool {
+	if sb.Declared == nil || sb.Actual == nil || *sb.Declared <= 0 {
+		return false
+	}
+
+	declared := float64(*sb.Declared)
+	return math.Abs(*sb.Actual-declared) > declared*tolerance
+}
+
+// SegmentBitrates returns the bitrates of every segment item in a playlist
+func (pl *Playlist) SegmentBitrates() []SegmentBitrate {
+	var (
+		bitrates []SegmentBitrate
+		declared *int
+	)
+
+	for _, item := range pl.Items {
+		switch it := item.(type) {
+		case *BitrateItem:
+			bitrate := it.Bitrate
+			declared = &bitrate
+		case *SegmentItem:
+			sb := SegmentBitrate{Segment: it, Declared: declared}
+			if it.ByteRange != nil && it.ByteRange.Length != nil && it.Duration > 0 {
+				actual := float64(*it.ByteRange.Length) * 8 / it.Duration / 1000
+				sb.Actual = &actual
+			}
+			bitrates = append(bitrates, sb)
+		}
+	}
+
+	return bitrates
+}
+
+// BitrateDeviations returns the segments whose actual bitrate deviates from
+// the declared one by more than BitrateTolerance
+func (pl *Playlist) BitrateDeviations() []SegmentBitrate {
+	var deviations []SegmentBitrate
+
+	for _, sb := range pl.SegmentBitrates() {
+		if sb.Deviates(BitrateTolerance) {
+			deviations = append(deviations, sb)
+		}
+	}
+
+	return deviations
+}
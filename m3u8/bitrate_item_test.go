@@ -0,0 +1,74 @@
+package m3u8
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitrateItem_Parse(t *testing.T) {
+	line := `#EXT-X-BITRATE:1500`
+
+	bi, err := NewBitrateItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, 1500, bi.Bitrate)
+	assert.Nil(t, bi.Validate())
+
+	assertToString(t, line, bi)
+
+	bi, err = NewBitrateItem(`#EXT-X-BITRATE:high`)
+	assert.Error(t, err)
+	assert.Nil(t, bi)
+}
+
+func TestPlaylist_SegmentBitrates(t *testing.T) {
+	s := strings.Join([]string{
+		HeaderTag,
+		TargetDurationTag + ":4",
+		"#EXTINF:4,",
+		"#EXT-X-BYTERANGE:500000@0",
+		"segment.ts",
+		"#EXT-X-BITRATE:1000",
+		"#EXTINF:4,",
+		"#EXT-X-BYTERANGE:500000",
+		"segment.ts",
+		"#EXTINF:4,",
+		"#EXT-X-BYTERANGE:560000",
+		"segment.ts",
+		"#EXTINF:4,",
+		"segment2.ts",
+		"#EXT-X-BITRATE:2000",
+		"#EXTINF:2,",
+		"#EXT-X-BYTERANGE:400000",
+		"segment.ts",
+	}, "\n")
+
+	pl, err := ReadString(s)
+	require.NoError(t, err)
+	assert.IsType(t, &BitrateItem{}, pl.Items[1])
+
+	bitrates := pl.SegmentBitrates()
+	require.Len(t, bitrates, 5)
+
+	assert.Nil(t, bitrates[0].Declared)
+	assertNotNilEqual(t, 1000.0, bitrates[0].Actual)
+	assertNotNilEqual(t, 1000, bitrates[1].Declared)
+	assertNotNilEqual(t, 1000.0, bitrates[1].Actual)
+	assertNotNilEqual(t, 1000, bitrates[2].Declared)
+	assertNotNilEqual(t, 1120.0, bitrates[2].Actual)
+	assertNotNilEqual(t, 1000, bitrates[3].Declared)
+	assert.Nil(t, bitrates[3].Actual)
+	assertNotNilEqual(t, 2000, bitrates[4].Declared)
+	assertNotNilEqual(t, 1600.0, bitrates[4].Actual)
+
+	deviations := pl.BitrateDeviations()
+	require.Len(t, deviations, 2)
+	assert.Equal(t, bitrates[2], deviations[0])
+	assert.Equal(t, bitrates[4], deviations[1])
+
+	decoded, err := ReadString(pl.String())
+	require.NoError(t, err)
+	assert.Equal(t, pl.Items, decoded.Items)
+}
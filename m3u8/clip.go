@@ -0,0 +1,194 @@
+package m3u8
+
+import "time"
+
+// Clip returns a VOD playlist of the segments of a media playlist overlapping a time range,
+// start and end are positions in seconds from the start of the playlist (see ResolvedSegment.Offset).
+// The clip keeps the media and discontinuity sequence numbers of its first segment,
+// starts with the #EXT-X-MAP and #EXT-X-KEY tags in effect and its date and time when known,
+// and keeps the date ranges overlapping it.
+func (pl *Playlist) Clip(start, end float64) (*Playlist, error) {
+	if pl.IsMaster() {
+		return nil, ErrPlaylistInvalidType
+	}
+	if end <= start {
+		return nil, ErrClipRangeInvalid
+	}
+
+	first, last := -1, -1
+	for i, rs := range pl.ResolvedSegments() {
+		if rs.Offset < end && rs.Offset+rs.Segment.Duration > start {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return nil, ErrSegmentNotFound
+	}
+
+	return pl.clip(first, last), nil
+}
+
+// ClipTime returns a VOD playlist of the segments of a media playlist overlapping a date and time range,
+// see Clip and ProgramDateTimes. Segments without date and time are kept only between the ones matching.
+func (pl *Playlist) ClipTime(start, end time.Time) (*Playlist, error) {
+	if pl.IsMaster() {
+		return nil, ErrPlaylistInvalidType
+	}
+	if !end.After(start) {
+		return nil, ErrClipRangeInvalid
+	}
+
+	segments := pl.Segments()
+	first, last := -1, -1
+	for i, t := range pl.ProgramDateTimes() {
+		if t == nil {
+			continue
+		}
+		if t.Before(end) && t.Add(seconds(segments[i].Duration)).After(start) {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return nil, ErrSegmentNotFound
+	}
+
+	return pl.clip(first, last), nil
+}
+
+// clip returns a VOD playlist of the segments from first to last (indexes of Segments)
+func (pl *Playlist) clip(first, last int) *Playlist {
+	resolved := pl.ResolvedSegments()
+	times := pl.ProgramDateTimes()
+	head := resolved[first]
+
+	// date and time range of the clip, when known
+	var from, to *time.Time
+	if times[first] != nil && times[last] != nil {
+		t := *times[last]
+		t = t.Add(seconds(resolved[last].Segment.Duration))
+		from, to = times[first], &t
+	}
+
+	// items of the clip: from the tags following the segment before the first one, to the last segment
+	var (
+		before  []Item
+		after   []Item
+		items   []Item
+		index   int
+		hasTime bool
+	)
+	for _, item := range pl.Items {
+		si, isSegment := item.(*SegmentItem)
+		switch {
+		case index < first:
+			// date ranges preceding the clip are kept if they overlap it
+			if dri, ok := item.(*DateRangeItem); ok && dateRangeOverlaps(dri, from, to) {
+				before = append(before, dri)
+			}
+			if isSegment {
+				index++
+			}
+			continue
+		case index > last:
+			// as well as the date ranges following it
+			if dri, ok := item.(*DateRangeItem); ok && dateRangeOverlaps(dri, from, to) {
+				after = append(after, dri)
+			}
+			continue
+		case index == first && !isSegment:
+			// the keys, map and date ranges in effect are written first
+			switch item.(type) {
+			case *KeyItem, *MapItem, *DiscontinuityItem, *DateRangeItem:
+				continue
+			case *TimeItem:
+				hasTime = true
+			}
+		}
+
+		if isSegment {
+			clone := *si
+			clone.Parts = nil
+			if index == first && clone.ProgramDateTime != nil {
+				hasTime = true
+			}
+			// the start of the first byte range may be implied by the preceding segment
+			if index == first && resolved[index].ByteRange != nil {
+				clone.ByteRange = resolved[index].ByteRange
+			}
+			item = &clone
+			index++
+		}
+		items = append(items, item)
+	}
+
+	var prefix []Item
+	if head.Map != nil {
+		prefix = append(prefix, head.Map)
+	}
+	for _, key := range head.Keys {
+		prefix = append(prefix, key)
+	}
+	prefix = append(prefix, before...)
+	for _, dri := range head.DateRanges {
+		prefix = append(prefix, dri)
+	}
+	if !hasTime && times[first] != nil {
+		prefix = append(prefix, &TimeItem{Time: *times[first]})
+	}
+
+	vod := PlaylistTypeVOD
+	master := false
+	clip := &Playlist{
+		Items:               append(append(prefix, items...), after...),
+		Version:             pl.Version,
+		Cache:               pl.Cache,
+		Target:              pl.Target,
+		Sequence:            head.MediaSequence,
+		Type:                &vod,
+		IFramesOnly:         pl.IFramesOnly,
+		IndependentSegments: pl.IndependentSegments,
+		Live:                false,
+		Master:              &master,
+	}
+	if pl.DiscontinuitySequence != nil || head.DiscontinuitySequence != 0 {
+		sequence := head.DiscontinuitySequence
+		clip.DiscontinuitySequence = &sequence
+	}
+
+	return clip
+}
+
+// dateRangeOverlaps checks if a date range overlaps a date and time range
+func dateRangeOverlaps(dri *DateRangeItem, from, to *time.Time) bool {
+	if from == nil || to == nil {
+		return false
+	}
+	start, err := ParseTime(dri.StartDate)
+	if err != nil {
+		return false
+	}
+
+	end := start
+	switch {
+	case dri.EndDate != nil:
+		if t, err := ParseTime(*dri.EndDate); err == nil {
+			end = t
+		}
+	case dri.Duration != nil:
+		end = start.Add(seconds(*dri.Duration))
+	case dri.PlannedDuration != nil:
+		end = start.Add(seconds(*dri.PlannedDuration))
+	}
+
+	if end.Equal(start) {
+		return !start.Before(*from) && start.Before(*to)
+	}
+
+	return start.Before(*to) && end.After(*from)
+}
@@ -0,0 +1,159 @@
+package m3u8
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var clipPlaylist = strings.Join([]string{
+	"#EXTM3U",
+	"#EXT-X-VERSION:6",
+	"#EXT-X-TARGETDURATION:10",
+	"#EXT-X-MEDIA-SEQUENCE:100",
+	"#EXT-X-DISCONTINUITY-SEQUENCE:2",
+	`#EXT-X-KEY:METHOD=AES-128,URI="https://example.com/key"`,
+	`#EXT-X-MAP:URI="init.mp4"`,
+	"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:00Z",
+	"#EXTINF:10,",
+	"s0.mp4",
+	`#EXT-X-DATERANGE:ID="early",START-DATE="2020-01-01T00:00:05Z",DURATION=2`,
+	`#EXT-X-DATERANGE:ID="long",START-DATE="2020-01-01T00:00:05Z",DURATION=30`,
+	"#EXTINF:10,",
+	"s1.mp4",
+	"#EXT-X-DISCONTINUITY",
+	`#EXT-X-MAP:URI="ad-init.mp4"`,
+	"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:20Z",
+	"#EXTINF:10,",
+	"ad0.mp4",
+	"#EXTINF:10,",
+	"ad1.mp4",
+	`#EXT-X-DATERANGE:ID="late",START-DATE="2020-01-01T00:00:25Z",DURATION=1`,
+	`#EXT-X-DATERANGE:ID="after",START-DATE="2020-01-01T00:01:00Z"`,
+	"#EXTINF:10,",
+	"s2.mp4",
+	"#EXT-X-RENDITION-REPORT:URI=\"other.m3u8\",LAST-MSN=104",
+}, "\n")
+
+func TestPlaylist_Clip(t *testing.T) {
+	pl, err := ReadString(clipPlaylist)
+	require.NoError(t, err)
+
+	clip, err := pl.Clip(25, 35)
+	require.NoError(t, err)
+
+	out, err := Write(clip)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-PLAYLIST-TYPE:VOD",
+		"#EXT-X-VERSION:6",
+		"#EXT-X-MEDIA-SEQUENCE:102",
+		"#EXT-X-DISCONTINUITY-SEQUENCE:3",
+		"#EXT-X-TARGETDURATION:10",
+		`#EXT-X-MAP:URI="ad-init.mp4"`,
+		`#EXT-X-KEY:METHOD=AES-128,URI="https://example.com/key"`,
+		`#EXT-X-DATERANGE:ID="long",START-DATE="2020-01-01T00:00:05Z",DURATION=30`,
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:20Z",
+		"#EXTINF:10,",
+		"ad0.mp4",
+		"#EXTINF:10,",
+		"ad1.mp4",
+		`#EXT-X-DATERANGE:ID="late",START-DATE="2020-01-01T00:00:25Z",DURATION=1`,
+		"#EXT-X-ENDLIST",
+		"",
+	}, "\n"), out)
+
+	// the key in effect is re-emitted
+	clip, err = pl.Clip(12, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, clip.SegmentSize())
+	assert.Equal(t, 101, clip.Sequence)
+	assert.Equal(t, 2, *clip.DiscontinuitySequence)
+	assert.IsType(t, &MapItem{}, clip.Items[0])
+	assert.IsType(t, &KeyItem{}, clip.Items[1])
+	require.Len(t, clip.ResolvedSegments(), 1)
+	assert.Equal(t, "init.mp4", clip.ResolvedSegments()[0].Map.URI)
+	assert.NotNil(t, clip.ResolvedSegments()[0].Key())
+	ts, err := clip.TimeOf(0)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(time.Date(2020, 1, 1, 0, 0, 10, 0, time.UTC)))
+	assert.False(t, clip.IsLive())
+
+	_, err = pl.Clip(10, 10)
+	assert.Equal(t, ErrClipRangeInvalid, err)
+	_, err = pl.Clip(100, 200)
+	assert.Equal(t, ErrSegmentNotFound, err)
+}
+
+func TestPlaylist_ClipTime(t *testing.T) {
+	pl, err := ReadString(clipPlaylist)
+	require.NoError(t, err)
+
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clip, err := pl.ClipTime(base.Add(5*time.Second), base.Add(21*time.Second))
+	require.NoError(t, err)
+
+	segments := clip.Segments()
+	require.Len(t, segments, 3)
+	assert.Equal(t, "s0.mp4", segments[0].Segment)
+	assert.Equal(t, "ad0.mp4", segments[2].Segment)
+	assert.Equal(t, 100, clip.Sequence)
+
+	var ids []string
+	for _, item := range clip.Items {
+		if dri, ok := item.(*DateRangeItem); ok {
+			ids = append(ids, dri.ID)
+		}
+	}
+	// date ranges overlapping the segments of the clip
+	assert.Equal(t, []string{"early", "long", "late"}, ids)
+
+	resolved := clip.ResolvedSegments()
+	assert.True(t, resolved[2].Discontinuity)
+	assert.Equal(t, 3, resolved[2].DiscontinuitySequence)
+	assert.Equal(t, "ad-init.mp4", resolved[2].Map.URI)
+	for _, item := range clip.Items {
+		_, ok := item.(*RenditionReportItem)
+		assert.False(t, ok)
+	}
+
+	// the original playlist is left untouched
+	assert.Equal(t, 5, pl.SegmentSize())
+	assert.True(t, pl.IsLive())
+
+	_, err = pl.ClipTime(base.Add(time.Hour), base.Add(2*time.Hour))
+	assert.Equal(t, ErrSegmentNotFound, err)
+}
+
+func TestPlaylist_Clip_ByteRange(t *testing.T) {
+	pl, err := ReadString(strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-VERSION:4",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:00Z",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:1000@0",
+		"main.ts",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:2000",
+		"main.ts",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:1500",
+		"main.ts",
+	}, "\n"))
+	require.NoError(t, err)
+
+	// the first segment of the clip gets the start of its byte range
+	clip, err := pl.Clip(12, 25)
+	require.NoError(t, err)
+	require.Equal(t, 2, clip.SegmentSize())
+	segments := clip.Segments()
+	assert.Equal(t, "2000@1000", segments[0].ByteRange.String())
+	assert.Equal(t, "1500", segments[1].ByteRange.String())
+	resolved := clip.ResolvedSegments()
+	assert.Equal(t, "1500@3000", resolved[1].ByteRange.String())
+}
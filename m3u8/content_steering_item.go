@@ -0,0 +1,253 @@
+package m3u8
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// DefaultPathwayID is the pathway of variant streams without PATHWAY-ID attribute
+const DefaultPathwayID = "."
+
+// ContentSteeringItem represents a #EXT-X-CONTENT-STEERING tag
+type ContentSteeringItem struct {
+	ServerURI  string
+	PathwayID  *string
+	attributes parser.AttributeList
+}
+
+// NewContentSteeringItem parses a text line and returns a *ContentSteeringItem
+func NewContentSteeringItem(text string) *ContentSteeringItem {
+	attributes := parser.ParseAttributes(text)
+
+	unknown := parser.ParseAttributeList(text).Without(
+		ServerURITag,
+		PathwayIDTag,
+	)
+
+	return &ContentSteeringItem{
+		ServerURI:  parser.SanitizeAttributeValue(attributes[ServerURITag]),
+		PathwayID:  parser.PointerTo(attributes, PathwayIDTag),
+		attributes: unknown,
+	}
+}
+
+func (csi *ContentSteeringItem) String() string {
+	slice := []string{fmt.Sprintf(parser.QuotedFormatString, ServerURITag, csi.ServerURI)}
+
+	if csi.PathwayID != nil {
+		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, PathwayIDTag, *csi.PathwayID))
+	}
+
+	slice = attributesJoin(slice, csi.attributes)
+
+	return fmt.Sprintf("%s:%s", ContentSteeringItemTag, strings.Join(slice, ","))
+}
+
+func (csi *ContentSteeringItem) Validate() []error {
+	if len(csi.ServerURI) == 0 {
+		return []error{fmt.Errorf("%s attribute is not valid", ServerURITag)}
+	}
+
+	return nil
+}
+
+// ContentSteering returns the content steering item of a master playlist, if any
+func (pl *Playlist) ContentSteering() *ContentSteeringItem {
+	for _, item := range pl.Items {
+		if csi, ok := item.(*ContentSteeringItem); ok {
+			return csi
+		}
+	}
+
+	return nil
+}
+
+// Pathway returns the pathway ID of a playlist item
+func (pi *PlaylistItem) Pathway() string {
+	if pi.PathwayID == nil {
+		return DefaultPathwayID
+	}
+
+	return *pi.PathwayID
+}
+
+// Pathway returns the pathway ID of a media item
+func (mi *MediaItem) Pathway() string {
+	if mi.PathwayID == nil {
+		return DefaultPathwayID
+	}
+
+	return *mi.PathwayID
+}
+
+// PathwayIDs returns the pathway IDs of the playlist items in order of appearance
+func (pl *Playlist) PathwayIDs() []string {
+	var ids []string
+	seen := make(map[string]bool)
+
+	for _, pi := range pl.Playlists() {
+		id := pi.Pathway()
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// Pathways groups the playlist items (variant streams) by pathway ID
+func (pl *Playlist) Pathways() map[string][]*PlaylistItem {
+	pathways := make(map[string][]*PlaylistItem)
+
+	for _, pi := range pl.Playlists() {
+		id := pi.Pathway()
+		pathways[id] = append(pathways[id], pi)
+	}
+
+	return pathways
+}
+
+// URIReplacement represents the modifications of the URIs of a cloned pathway
+// (URI-REPLACEMENT of a content steering manifest)
+type URIReplacement struct {
+	// Host replaces the host of absolute URIs, kept when empty
+	Host string
+	// Params are added to the query of URIs, replacing the parameters of the same name
+	Params map[string]string
+	// PerVariantURIs replaces the URIs of variant streams by STABLE-VARIANT-ID, instead of Host and Params
+	PerVariantURIs map[string]string
+	// PerRenditionURIs replaces the URIs of renditions by STABLE-RENDITION-ID, instead of Host and Params
+	PerRenditionURIs map[string]string
+}
+
+// ClonePathway appends a copy of every playlist item and media item of the pathway baseID
+// with the pathway ID set to id and the host of absolute URIs replaced by host,
+// relative URIs are kept as is, as well as the hosts when host is empty
+func (pl *Playlist) ClonePathway(baseID, id, host string) error {
+	return pl.ClonePathwayWith(baseID, id, URIReplacement{Host: host})
+}
+
+// ClonePathwayWith appends a copy of every playlist item and media item of the pathway baseID
+// with the pathway ID set to id and the URIs modified by a URI replacement
+func (pl *Playlist) ClonePathwayWith(baseID, id string, r URIReplacement) error {
+	if baseID == id {
+		return ErrPathwayInvalid
+	}
+	if err := validateHost(r.Host); err != nil {
+		return err
+	}
+
+	var clones []Item
+	for _, item := range pl.Items {
+		switch it := item.(type) {
+		case *PlaylistItem:
+			if it.Pathway() == id {
+				return ErrPathwayInvalid
+			}
+			if it.Pathway() != baseID {
+				continue
+			}
+			uri, err := r.replace(it.URI, it.StableVariantID, r.PerVariantURIs)
+			if err != nil {
+				return err
+			}
+			clone := *it
+			clone.URI = uri
+			clone.PathwayID = &id
+			clone.attributes = it.attributes.Copy()
+			clones = append(clones, &clone)
+		case *MediaItem:
+			if it.Pathway() == id {
+				return ErrPathwayInvalid
+			}
+			if it.Pathway() != baseID {
+				continue
+			}
+			clone := *it
+			if it.URI != nil {
+				uri, err := r.replace(*it.URI, it.StableRenditionId, r.PerRenditionURIs)
+				if err != nil {
+					return err
+				}
+				clone.URI = &uri
+			}
+			clone.PathwayID = &id
+			clone.attributes = it.attributes.Copy()
+			clones = append(clones, &clone)
+		}
+	}
+
+	if len(clones) == 0 {
+		return ErrPathwayInvalid
+	}
+	pl.Items = append(pl.Items, clones...)
+
+	return nil
+}
+
+// replace returns the URI of a clone: the URI of its stable ID when set, the URI with host and params replaced otherwise
+func (r URIReplacement) replace(uri string, stableID *string, perIDURIs map[string]string) (string, error) {
+	if stableID != nil {
+		if replacement, ok := perIDURIs[*stableID]; ok {
+			return replacement, nil
+		}
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if r.Host != "" && u.IsAbs() && u.Host != "" {
+		u.Host = r.Host
+	}
+	if len(r.Params) > 0 {
+		u.RawQuery = replaceParams(u.RawQuery, r.Params)
+	}
+
+	return u.String(), nil
+}
+
+// replaceParams sets params in a query, keeping the order of the other parameters, new ones are appended by name
+func replaceParams(query string, params map[string]string) string {
+	var parts []string
+	if query != "" {
+		for _, part := range strings.Split(query, "&") {
+			name := strings.SplitN(part, "=", 2)[0]
+			if unescaped, err := url.QueryUnescape(name); err == nil {
+				name = unescaped
+			}
+			if _, ok := params[name]; !ok {
+				parts = append(parts, part)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, url.QueryEscape(name)+"="+url.QueryEscape(params[name]))
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// validateHost checks that host is empty or a host with an optional port, without scheme, path or user info
+func validateHost(host string) error {
+	if host == "" {
+		return nil
+	}
+	u, err := url.Parse("//" + host)
+	if err != nil || u.Host != host || u.Hostname() == "" || u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%w: %q", ErrHostInvalid, host)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package m3u8
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentSteeringItem_Parse(t *testing.T) {
+	line := `#EXT-X-CONTENT-STEERING:SERVER-URI="https://steering.example.com/steering.json",PATHWAY-ID="CDN-A"`
+
+	csi := NewContentSteeringItem(line)
+	assert.Equal(t, "https://steering.example.com/steering.json", csi.ServerURI)
+	assertNotNilEqual(t, "CDN-A", csi.PathwayID)
+	assert.Nil(t, csi.Validate())
+
+	assertToString(t, line, csi)
+
+	csi = NewContentSteeringItem(`#EXT-X-CONTENT-STEERING:PATHWAY-ID="CDN-A"`)
+	assert.Len(t, csi.Validate(), 1)
+}
+
+func TestPlaylist_Pathways(t *testing.T) {
+	p, err := ReadFile("fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+	assert.True(t, p.IsMaster())
+
+	csi := p.ContentSteering()
+	require.NotNil(t, csi)
+	assertNotNilEqual(t, "CDN-A", csi.PathwayID)
+
+	assert.Equal(t, []string{"CDN-A", "CDN-B"}, p.PathwayIDs())
+	pathways := p.Pathways()
+	require.Len(t, pathways, 2)
+	require.Len(t, pathways["CDN-A"], 2)
+	assert.Equal(t, "https://cdn-a.example.com/video/low.m3u8", pathways["CDN-A"][0].URI)
+	assert.Equal(t, "https://cdn-b.example.com/video/mid.m3u8", pathways["CDN-B"][1].URI)
+
+	mi := p.Items[1].(*MediaItem)
+	assertNotNilEqual(t, "CDN-A", mi.PathwayID)
+	assert.Equal(t, "CDN-A", mi.Pathway())
+
+	p = &Playlist{Items: []Item{&PlaylistItem{Bandwidth: 1, URI: "low.m3u8"}}}
+	assert.Equal(t, []string{DefaultPathwayID}, p.PathwayIDs())
+}
+
+func TestPlaylist_ClonePathway(t *testing.T) {
+	p, err := ReadFile("fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+	itemSize := p.ItemSize()
+
+	require.NoError(t, p.ClonePathway("CDN-A", "CDN-C", "cdn-c.example.com"))
+	assert.Equal(t, itemSize+3, p.ItemSize())
+	assert.Equal(t, []string{"CDN-A", "CDN-B", "CDN-C"}, p.PathwayIDs())
+
+	clones := p.Pathways()["CDN-C"]
+	require.Len(t, clones, 2)
+	assert.Equal(t, "https://cdn-c.example.com/video/low.m3u8", clones[0].URI)
+	assert.Equal(t, 1280000, clones[0].Bandwidth)
+	assertNotNilEqual(t, "aac", clones[0].Audio)
+	// the base pathway is left intact
+	assert.Equal(t, "https://cdn-a.example.com/video/low.m3u8", p.Pathways()["CDN-A"][0].URI)
+
+	mi := p.Items[itemSize].(*MediaItem)
+	assertNotNilEqual(t, "CDN-C", mi.PathwayID)
+	assertNotNilEqual(t, "https://cdn-c.example.com/audio/en.m3u8", mi.URI)
+
+	decoded, err := ReadString(p.String())
+	require.NoError(t, err)
+	assert.Equal(t, p.Items, decoded.Items)
+
+	assert.Equal(t, ErrPathwayInvalid, p.ClonePathway("CDN-A", "CDN-B", "cdn-c.example.com"))
+	assert.Equal(t, ErrPathwayInvalid, p.ClonePathway("CDN-D", "CDN-E", "cdn-e.example.com"))
+	assert.Equal(t, ErrPathwayInvalid, p.ClonePathway("CDN-A", "CDN-A", "cdn-a.example.com"))
+}
+
+func TestPlaylist_ClonePathway_Relative(t *testing.T) {
+	p, err := ReadFile("fixtures/master.m3u8")
+	require.NoError(t, err)
+
+	require.NoError(t, p.ClonePathway(DefaultPathwayID, "CDN-B", "cdn-b.example.com"))
+	clones := p.Pathways()["CDN-B"]
+	require.Len(t, clones, 6)
+	assert.Equal(t, "hls/1080-7mbps/1080-7mbps.m3u8", clones[0].URI)
+}
+
+func TestPlaylist_ClonePathway_Host(t *testing.T) {
+	p, err := ReadFile("fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+
+	// without host, the URIs are kept as is
+	require.NoError(t, p.ClonePathway("CDN-A", "CDN-C", ""))
+	clones := p.Pathways()["CDN-C"]
+	require.Len(t, clones, 2)
+	assert.Equal(t, "https://cdn-a.example.com/video/low.m3u8", clones[0].URI)
+
+	require.NoError(t, p.ClonePathway("CDN-A", "CDN-D", "cdn-d.example.com:8443"))
+	assert.Equal(t, "https://cdn-d.example.com:8443/video/low.m3u8", p.Pathways()["CDN-D"][0].URI)
+
+	itemSize := p.ItemSize()
+	for _, host := range []string{"https://cdn-e.example.com", "cdn-e.example.com/path", "user@cdn-e.example.com", ":8443"} {
+		err := p.ClonePathway("CDN-A", "CDN-E", host)
+		assert.True(t, errors.Is(err, ErrHostInvalid), host)
+	}
+	assert.Equal(t, itemSize, p.ItemSize())
+}
+
+func TestPlaylist_ClonePathwayWith(t *testing.T) {
+	p, err := ReadString(strings.Join([]string{
+		"#EXTM3U",
+		`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="English",URI="https://cdn-a.example.com/audio/en.m3u8?token=a&x=1",STABLE-RENDITION-ID="en",PATHWAY-ID="CDN-A"`,
+		`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="French",URI="audio/fr.m3u8",STABLE-RENDITION-ID="fr",PATHWAY-ID="CDN-A"`,
+		`#EXT-X-STREAM-INF:BANDWIDTH=1280000,AUDIO="aac",STABLE-VARIANT-ID="low",PATHWAY-ID="CDN-A"`,
+		"https://cdn-a.example.com/video/low.m3u8",
+		`#EXT-X-STREAM-INF:BANDWIDTH=2560000,AUDIO="aac",STABLE-VARIANT-ID="mid",PATHWAY-ID="CDN-A"`,
+		"https://cdn-a.example.com/video/mid.m3u8",
+	}, "\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, p.ClonePathwayWith("CDN-A", "CDN-B", URIReplacement{
+		Host:             "cdn-b.example.com",
+		Params:           map[string]string{"token": "b c", "cdn": "b"},
+		PerVariantURIs:   map[string]string{"mid": "https://other.example.com/mid.m3u8"},
+		PerRenditionURIs: map[string]string{"fr": "https://other.example.com/fr.m3u8"},
+	}))
+
+	variants := p.Pathways()["CDN-B"]
+	require.Len(t, variants, 2)
+	assert.Equal(t, "https://cdn-b.example.com/video/low.m3u8?cdn=b&token=b+c", variants[0].URI)
+	assert.Equal(t, "https://other.example.com/mid.m3u8", variants[1].URI)
+
+	var renditions []string
+	for _, item := range p.Items {
+		if mi, ok := item.(*MediaItem); ok && mi.Pathway() == "CDN-B" {
+			renditions = append(renditions, *mi.URI)
+		}
+	}
+	assert.Equal(t, []string{
+		"https://cdn-b.example.com/audio/en.m3u8?x=1&cdn=b&token=b+c",
+		"https://other.example.com/fr.m3u8",
+	}, renditions)
+}
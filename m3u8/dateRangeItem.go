@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/scte35"
 )
 
 // DateRangeItem represents a #EXT-X-DATERANGE tag
@@ -21,13 +22,15 @@ type DateRangeItem struct {
 	Scte35In         *string
 	EndOnNext        bool
 	ClientAttributes map[string]string
+	// clientAttributesOrder holds the original order of the client attributes
+	clientAttributesOrder []string
 }
 
 // NewDateRangeItem parses a text line in playlist and returns a *DateRangeItem
 func NewDateRangeItem(text string) *DateRangeItem {
-	attributes := parser.ParseAttributes(text)
-
-	defer deleteKeys(attributes,
+	list := parser.ParseAttributeList(text)
+	attributes := list.Map()
+	unknown := list.Without(
 		DurationTag,
 		PlannedDurationTag,
 		IDTag,
@@ -42,18 +45,19 @@ func NewDateRangeItem(text string) *DateRangeItem {
 	)
 
 	return &DateRangeItem{
-		ID:               parser.SanitizeAttributeValue(attributes[IDTag]),
-		Class:            parser.PointerTo(attributes, ClassTag),
-		StartDate:        parser.SanitizeAttributeValue(attributes[StartDateTag]),
-		Cue:              parser.PointerTo(attributes, CueTag),
-		EndDate:          parser.PointerTo(attributes, EndDateTag),
-		Duration:         parser.PointerToFloat(attributes, DurationTag),
-		PlannedDuration:  parser.PointerToFloat(attributes, PlannedDurationTag),
-		Scte35Cmd:        parser.PointerTo(attributes, Scte35CmdTag),
-		Scte35Out:        parser.PointerTo(attributes, Scte35OutTag),
-		Scte35In:         parser.PointerTo(attributes, Scte35InTag),
-		EndOnNext:        parser.AttributeExists(EndOnNextTag, attributes),
-		ClientAttributes: attributes,
+		ID:                    parser.SanitizeAttributeValue(attributes[IDTag]),
+		Class:                 parser.PointerTo(attributes, ClassTag),
+		StartDate:             parser.SanitizeAttributeValue(attributes[StartDateTag]),
+		Cue:                   parser.PointerTo(attributes, CueTag),
+		EndDate:               parser.PointerTo(attributes, EndDateTag),
+		Duration:              parser.PointerToFloat(attributes, DurationTag),
+		PlannedDuration:       parser.PointerToFloat(attributes, PlannedDurationTag),
+		Scte35Cmd:             parser.PointerTo(attributes, Scte35CmdTag),
+		Scte35Out:             parser.PointerTo(attributes, Scte35OutTag),
+		Scte35In:              parser.PointerTo(attributes, Scte35InTag),
+		EndOnNext:             parser.AttributeExists(EndOnNextTag, attributes),
+		ClientAttributes:      unknown.Map(),
+		clientAttributesOrder: unknown.Names(),
 	}
 }
 
@@ -77,7 +81,7 @@ func (dri *DateRangeItem) String() string {
 	if dri.PlannedDuration != nil {
 		slice = append(slice, fmt.Sprintf(parser.FormatString, PlannedDurationTag, *dri.PlannedDuration))
 	}
-	clientAttributes := formatClientAttributes(dri.ClientAttributes)
+	clientAttributes := formatClientAttributes(dri.ClientAttributes, dri.clientAttributesOrder)
 	slice = append(slice, clientAttributes...)
 
 	if dri.Scte35Cmd != nil {
@@ -100,12 +104,73 @@ func (dri *DateRangeItem) Validate() []error {
 	return nil
 }
 
-func formatClientAttributes(ca map[string]string) []string {
+// Scte35CmdInfo decodes the splice_info_section of SCTE35-CMD (hexadecimal), nil when not set
+func (dri *DateRangeItem) Scte35CmdInfo() (*scte35.SpliceInfoSection, error) {
+	return decodeHexSpliceInfo(dri.Scte35Cmd)
+}
+
+// Scte35OutInfo decodes the splice_info_section of SCTE35-OUT (hexadecimal), nil when not set
+func (dri *DateRangeItem) Scte35OutInfo() (*scte35.SpliceInfoSection, error) {
+	return decodeHexSpliceInfo(dri.Scte35Out)
+}
+
+// Scte35InInfo decodes the splice_info_section of SCTE35-IN (hexadecimal), nil when not set
+func (dri *DateRangeItem) Scte35InInfo() (*scte35.SpliceInfoSection, error) {
+	return decodeHexSpliceInfo(dri.Scte35In)
+}
+
+// SetScte35Cmd sets SCTE35-CMD to a splice_info_section encoded in hexadecimal
+func (dri *DateRangeItem) SetScte35Cmd(section *scte35.SpliceInfoSection) error {
+	return encodeHexSpliceInfo(section, &dri.Scte35Cmd)
+}
+
+// SetScte35Out sets SCTE35-OUT to a splice_info_section encoded in hexadecimal
+func (dri *DateRangeItem) SetScte35Out(section *scte35.SpliceInfoSection) error {
+	return encodeHexSpliceInfo(section, &dri.Scte35Out)
+}
+
+// SetScte35In sets SCTE35-IN to a splice_info_section encoded in hexadecimal
+func (dri *DateRangeItem) SetScte35In(section *scte35.SpliceInfoSection) error {
+	return encodeHexSpliceInfo(section, &dri.Scte35In)
+}
+
+func decodeHexSpliceInfo(value *string) (*scte35.SpliceInfoSection, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return scte35.DecodeHex(*value)
+}
+
+func encodeHexSpliceInfo(section *scte35.SpliceInfoSection, value **string) error {
+	text, err := section.Hex()
+	if err != nil {
+		return err
+	}
+	*value = &text
+
+	return nil
+}
+
+// formatClientAttributes formats client attributes in their original order,
+// followed by the ones added since sorted by name
+func formatClientAttributes(ca map[string]string, order []string) []string {
 	var slice []string
 
-	for key, value := range ca {
+	written := make(map[string]bool, len(order))
+	for _, key := range order {
+		value, ok := ca[key]
+		if !ok || written[key] {
+			continue
+		}
+		written[key] = true
 		slice = append(slice, fmt.Sprintf(parser.FormatString, key, value))
 	}
+	for _, a := range sortedAttributes(ca) {
+		if !written[a.Name] {
+			slice = append(slice, fmt.Sprintf(parser.FormatString, a.Name, a.Value))
+		}
+	}
 
 	return slice
 }
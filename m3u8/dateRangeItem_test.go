@@ -1,10 +1,15 @@
 package m3u8
 
 import (
-	"github.com/AlekSi/pointer"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/AlekSi/pointer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/scte35"
 )
 
 func TestDateRangeItem_Parse(t *testing.T) {
@@ -71,3 +76,57 @@ X-CUSTOM-VALUE="test_value"
 
 	assertToString(t, line, dri)
 }
+
+func TestDateRangeItem_ClientAttributesOrder(t *testing.T) {
+	line := `#EXT-X-DATERANGE:ID="ad",START-DATE="2014-03-05T11:15:00Z",X-Z="1",X-B="2",X-Y=3,X-A="4"`
+	dri := NewDateRangeItem(line)
+	assert.Equal(t, line, dri.String())
+
+	delete(dri.ClientAttributes, "X-B")
+	dri.ClientAttributes["X-D"] = `"5"`
+	dri.ClientAttributes["X-C"] = `"6"`
+	assert.Equal(t,
+		`#EXT-X-DATERANGE:ID="ad",START-DATE="2014-03-05T11:15:00Z",X-Z="1",X-Y=3,X-A="4",X-C="6",X-D="5"`,
+		dri.String())
+}
+
+func TestDateRangeItem_Scte35Info(t *testing.T) {
+	pl, err := ReadFile("fixtures/sle_drm.m3u8")
+	require.NoError(t, err)
+
+	count := 0
+	for _, item := range pl.Items {
+		dri, ok := item.(*DateRangeItem)
+		if !ok || dri.Scte35Out == nil {
+			continue
+		}
+		count++
+
+		section, err := dri.Scte35OutInfo()
+		require.NoError(t, err, *dri.Scte35Out)
+		d := section.SegmentationDescriptors()[0]
+		assert.True(t, strings.HasPrefix(dri.ID, fmt.Sprintf("%s-%d-", d.TypeID.Hex(), d.EventID)), dri.ID)
+		require.NotNil(t, dri.PlannedDuration)
+		assert.InDelta(t, *dri.PlannedDuration, *d.DurationSeconds(), 0.0005)
+
+		encoded := &DateRangeItem{}
+		require.NoError(t, encoded.SetScte35Out(section))
+		assert.Equal(t, dri.Scte35Out, encoded.Scte35Out)
+
+		section, err = dri.Scte35InInfo()
+		assert.NoError(t, err)
+		assert.Nil(t, section)
+	}
+	assert.NotZero(t, count)
+
+	dri := &DateRangeItem{Scte35Cmd: pointer.ToString("0xFC00")}
+	_, err = dri.Scte35CmdInfo()
+	assert.Error(t, err)
+
+	require.NoError(t, dri.SetScte35Cmd(scte35.NewSpliceInfoSection(&scte35.SpliceNull{})))
+	section, err := dri.Scte35CmdInfo()
+	require.NoError(t, err)
+	assert.Equal(t, &scte35.SpliceNull{}, section.SpliceCommand)
+	require.NoError(t, dri.SetScte35In(section))
+	assert.Equal(t, dri.Scte35Cmd, dri.Scte35In)
+}
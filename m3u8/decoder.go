@@ -0,0 +1,201 @@
+package m3u8
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxLineSize is the maximum length of a playlist line the Decoder accepts
+const maxLineSize = 16 * 1024 * 1024
+
+// Decoder reads a playlist from an io.Reader line by line, so items can be processed
+// as they are parsed without keeping the whole playlist in memory
+type Decoder struct {
+	scanner   *bufio.Scanner
+	pl        *Playlist
+	st        *state
+	header    bool
+	eof       bool
+	queue     []Item
+	playlists int
+	segments  int
+	warnings  []error
+	src       *source
+}
+
+// NewDecoder returns a *Decoder reading from an io.Reader in lenient mode
+func NewDecoder(reader io.Reader) *Decoder {
+	return NewDecoderWithOptions(reader, ReadOptions{})
+}
+
+// NewDecoderWithOptions returns a *Decoder reading from an io.Reader
+func NewDecoderWithOptions(reader io.Reader, opts ReadOptions) *Decoder {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	var src *source
+	if opts.Lossless {
+		src = &source{}
+		scanner.Split(src.scanLines)
+	}
+
+	return &Decoder{
+		scanner: scanner,
+		pl:      NewPlaylist(),
+		st:      &state{strict: opts.Strict, tags: opts.Tags},
+		header:  true,
+		src:     src,
+	}
+}
+
+// Next returns the next item of the playlist, or io.EOF when all items have been read.
+// Items are not collected into Playlist().Items.
+func (d *Decoder) Next() (Item, error) {
+	for len(d.queue) == 0 {
+		if d.eof {
+			return nil, io.EOF
+		}
+		if err := d.readLine(); err != nil {
+			return nil, err
+		}
+	}
+
+	item := d.queue[0]
+	d.queue = d.queue[1:]
+
+	return item, nil
+}
+
+// Playlist returns the playlist attributes (version, target duration etc.) read so far,
+// they are complete once Next has returned io.EOF
+func (d *Decoder) Playlist() *Playlist {
+	return d.pl
+}
+
+// Warnings returns the errors of the invalid tags read so far (kept as UnknownItem)
+func (d *Decoder) Warnings() []error {
+	return d.warnings
+}
+
+// Decode reads all items and returns the playlist
+func (d *Decoder) Decode() (*Playlist, error) {
+	var items []Item
+
+	for {
+		item, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	pl := d.Playlist()
+	pl.Items = items
+	if d.src != nil {
+		d.src.snapshot(pl)
+		pl.source = d.src
+	}
+
+	return pl, nil
+}
+
+// readLine parses the next line and queues the items which are complete
+func (d *Decoder) readLine() error {
+	if !d.scanner.Scan() {
+		if err := d.scanner.Err(); err != nil {
+			return err
+		}
+		return d.finish()
+	}
+
+	d.st.line++
+	text := d.scanner.Text()
+	value := strings.TrimSpace(text)
+	if len(value) == 0 {
+		d.record(text, value, 0)
+		return nil
+	}
+	if d.header && value != HeaderTag {
+		return ErrPlaylistInvalid
+	}
+
+	if value == HeaderTag {
+		if !d.header {
+			return ErrPlaylistInvalid
+		}
+		d.header = false
+		d.record(text, value, 0)
+		return nil
+	}
+
+	n := len(d.pl.Items)
+	if err := parseLine(value, d.pl, d.st); err != nil {
+		return err
+	}
+	d.record(text, value, n)
+
+	// parts not yet claimed by a segment stay in the playlist until #EXTINF or the end
+	ready := len(d.pl.Items)
+	if len(d.st.parts) > 0 {
+		ready = d.st.partsStart
+	}
+
+	return d.enqueue(ready)
+}
+
+// record adds a line to the source in lossless mode, n is the number of playlist items before the line
+func (d *Decoder) record(text, value string, n int) {
+	if d.src == nil {
+		return
+	}
+
+	var appended []Item
+	if len(d.pl.Items) > n {
+		appended = d.pl.Items[n:]
+	}
+	d.src.record(text, value, appended)
+}
+
+func (d *Decoder) finish() error {
+	d.eof = true
+	if err := d.enqueue(len(d.pl.Items)); err != nil {
+		return err
+	}
+
+	if d.pl.Version == nil {
+		version := 1
+		d.pl.Version = &version
+	}
+
+	return nil
+}
+
+// enqueue moves the first n playlist items to the queue
+func (d *Decoder) enqueue(n int) error {
+	for _, item := range d.pl.Items[:n] {
+		switch it := item.(type) {
+		case *PlaylistItem:
+			d.playlists++
+		case *SegmentItem:
+			d.segments++
+		case *UnknownItem:
+			if it.Err() != nil {
+				d.warnings = append(d.warnings, it.Err())
+			}
+		}
+		d.queue = append(d.queue, item)
+	}
+	if d.playlists > 0 && d.segments > 0 {
+		return ErrPlaylistInvalidType
+	}
+
+	rest := copy(d.pl.Items, d.pl.Items[n:])
+	d.pl.Items = d.pl.Items[:rest]
+	d.st.partsStart -= n
+
+	return nil
+}
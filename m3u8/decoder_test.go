@@ -0,0 +1,96 @@
+package m3u8
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_Next(t *testing.T) {
+	f, err := os.Open("fixtures/llhls.m3u8")
+	require.NoError(t, err)
+	defer f.Close()
+
+	d := NewDecoder(f)
+	var items []Item
+	for {
+		item, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		items = append(items, item)
+		// items are not collected by the decoder
+		assert.True(t, len(d.Playlist().Items) <= 3)
+	}
+
+	_, err = d.Next()
+	assert.Equal(t, io.EOF, err)
+
+	pl := d.Playlist()
+	assert.Empty(t, pl.Items)
+	assert.Equal(t, 266, pl.Sequence)
+	assert.Equal(t, 4, pl.Target)
+	assertNotNilEqual(t, 6, pl.Version)
+	assert.NotNil(t, pl.ServerControl)
+
+	expected, err := ReadFile("fixtures/llhls.m3u8")
+	require.NoError(t, err)
+	assert.Equal(t, expected.Items, items)
+
+	// trailing parts are returned after the last segment
+	assert.IsType(t, &PartItem{}, items[len(items)-6])
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	f, err := os.ReadFile("fixtures/fer_with_ads.m3u8")
+	require.NoError(t, err)
+
+	pl, err := NewDecoder(strings.NewReader(string(f))).Decode()
+	require.NoError(t, err)
+	assert.True(t, pl.IsValid())
+	assert.False(t, pl.IsLive())
+	assert.NotEmpty(t, pl.Items)
+
+	decoded, err := ReadString(pl.String())
+	require.NoError(t, err)
+	assert.Equal(t, pl.Items, decoded.Items)
+}
+
+func TestDecoder_Invalid(t *testing.T) {
+	d := NewDecoder(strings.NewReader(strings.Join([]string{
+		HeaderTag,
+		"#EXT-X-STREAM-INF:BANDWIDTH=540",
+		"test.m3u8",
+		"#EXTINF:10.991,",
+		"test.ts",
+	}, "\n")))
+
+	item, err := d.Next()
+	require.NoError(t, err)
+	assert.IsType(t, &PlaylistItem{}, item)
+	_, err = d.Next()
+	assert.Equal(t, ErrPlaylistInvalidType, err)
+
+	d = NewDecoder(strings.NewReader("#EXTINF:10.991,\ntest.ts"))
+	_, err = d.Next()
+	assert.Equal(t, ErrPlaylistInvalid, err)
+
+	readErr := errors.New("read error")
+	d = NewDecoder(io.MultiReader(strings.NewReader(HeaderTag+"\n"), &errorReader{err: readErr}))
+	_, err = d.Decode()
+	assert.Equal(t, readErr, err)
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
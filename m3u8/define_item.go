@@ -13,14 +13,14 @@ type DefineItem struct {
 	Value      *string
 	Import     *string
 	QueryParam *string
-	attributes map[string]string
+	attributes parser.AttributeList
 }
 
 // NewDefineItem parses a text line and returns a *DefineItem
 func NewDefineItem(text string) *DefineItem {
 	attributes := parser.ParseAttributes(text)
 
-	defer deleteKeys(attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		AttributeName,
 		AttributeValue,
 		AttributeImport,
@@ -32,7 +32,7 @@ func NewDefineItem(text string) *DefineItem {
 		Value:      parser.PointerTo(attributes, AttributeValue),
 		Import:     parser.PointerTo(attributes, AttributeImport),
 		QueryParam: parser.PointerTo(attributes, AttributeQueryParam),
-		attributes: attributes,
+		attributes: unknown,
 	}
 }
 
@@ -54,9 +54,7 @@ func (i *DefineItem) String() string {
 		attributes = append(attributes, fmt.Sprintf(parser.QuotedFormatString, AttributeQueryParam, *i.QueryParam))
 	}
 
-	for attributeKey, attribute := range i.attributes {
-		attributes = append(attributes, fmt.Sprintf(parser.FormatString, attributeKey, attribute))
-	}
+	attributes = attributesJoin(attributes, i.attributes)
 
 	return fmt.Sprintf("%s:%v", DefineTag, strings.Join(attributes, ","))
 }
@@ -38,7 +38,6 @@ func TestNewDefineItem(t *testing.T) {
 				Value:      &expectedValue,
 				Import:     &expectedImp,
 				QueryParam: &expectedQueryParam,
-				attributes: make(map[string]string),
 			},
 			expectedAttributesEncoded: []string{
 				encodedName,
@@ -55,10 +54,9 @@ func TestNewDefineItem(t *testing.T) {
 				encodedImp,
 			),
 			expectDecoded: &DefineItem{
-				Name:       "name",
-				Value:      &expectedValue,
-				Import:     &expectedImp,
-				attributes: make(map[string]string),
+				Name:   "name",
+				Value:  &expectedValue,
+				Import: &expectedImp,
 			},
 			expectedAttributesEncoded: []string{
 				encodedName,
@@ -73,9 +71,8 @@ func TestNewDefineItem(t *testing.T) {
 				encodedValue,
 			),
 			expectDecoded: &DefineItem{
-				Name:       "name",
-				Value:      &expectedValue,
-				attributes: make(map[string]string),
+				Name:  "name",
+				Value: &expectedValue,
 			},
 			expectedAttributesEncoded: []string{
 				encodedName,
@@ -88,8 +85,7 @@ func TestNewDefineItem(t *testing.T) {
 				encodedName,
 			),
 			expectDecoded: &DefineItem{
-				Name:       "name",
-				attributes: make(map[string]string),
+				Name: "name",
 			},
 			expectedAttributesEncoded: []string{
 				encodedName,
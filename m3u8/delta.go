@@ -0,0 +1,212 @@
+package m3u8
+
+// deltaUpdateVersion is the minimal protocol version of playlists with #EXT-X-SKIP
+const deltaUpdateVersion = 9
+
+// ApplyDelta reconstitutes a full media playlist from a playlist delta update
+// (a playlist containing #EXT-X-SKIP) and the previous full playlist.
+// Skipped segments are taken from the previous playlist, lined up by media sequence number,
+// together with the tags preceding them and the date ranges still active;
+// date ranges listed in RECENTLY-REMOVED-DATERANGES are dropped.
+// A delta without #EXT-X-SKIP is returned as is.
+func ApplyDelta(previous, delta *Playlist) (*Playlist, error) {
+	skipIndex := -1
+	for i, item := range delta.Items {
+		if _, ok := item.(*SkipItem); ok {
+			skipIndex = i
+			break
+		}
+	}
+	if skipIndex < 0 {
+		return delta, nil
+	}
+	skip := delta.Items[skipIndex].(*SkipItem)
+
+	first := delta.Sequence
+	last := delta.Sequence + skip.SkippedSegments
+	if previous.IsMaster() || first < previous.Sequence || last > previous.Sequence+previous.SegmentSize() {
+		return nil, ErrDeltaPlaylistInvalid
+	}
+
+	removed := make(map[string]bool, len(skip.RecentlyRemovedDateRanges))
+	for _, id := range skip.RecentlyRemovedDateRanges {
+		removed[id] = true
+	}
+	// date ranges of skipped segments are repeated in the delta unless they are skipped too
+	sent := make(map[string]*DateRangeItem)
+	for _, item := range delta.Items {
+		if dri, ok := item.(*DateRangeItem); ok {
+			sent[dri.ID] = dri
+		}
+	}
+	moved := make(map[string]bool)
+
+	var (
+		skipped    []Item
+		dateRanges []Item
+		key        *KeyItem
+		mapItem    *MapItem
+		hasKey     bool
+		hasMap     bool
+	)
+	sequence := previous.Sequence
+	for _, item := range previous.Items {
+		switch {
+		case sequence < first:
+			// keep track of the encryption and initialization section in effect
+			switch it := item.(type) {
+			case *KeyItem:
+				key = it
+			case *MapItem:
+				mapItem = it
+			case *DateRangeItem:
+				// as well as the date ranges still active
+				if skip.RecentlyRemovedDateRanges != nil && !removed[it.ID] && sent[it.ID] == nil {
+					dateRanges = append(dateRanges, it)
+				}
+			}
+		case sequence < last:
+			if dri, ok := item.(*DateRangeItem); ok {
+				if removed[dri.ID] {
+					break
+				}
+				if sent[dri.ID] != nil {
+					item = sent[dri.ID]
+					moved[dri.ID] = true
+				}
+			}
+			if sequence == first {
+				switch item.(type) {
+				case *KeyItem:
+					hasKey = true
+				case *MapItem:
+					hasMap = true
+				}
+			}
+			skipped = append(skipped, item)
+		default:
+			// with skipped date ranges the delta omits those the client already has
+			dri, ok := item.(*DateRangeItem)
+			if ok && skip.RecentlyRemovedDateRanges != nil && !removed[dri.ID] && sent[dri.ID] == nil {
+				dateRanges = append(dateRanges, dri)
+			}
+		}
+
+		if _, ok := item.(*SegmentItem); ok {
+			sequence++
+		}
+	}
+
+	items := make([]Item, 0, len(previous.Items)+len(delta.Items))
+	if mapItem != nil && !hasMap {
+		items = append(items, mapItem)
+	}
+	if key != nil && !hasKey {
+		items = append(items, key)
+	}
+	items = append(items, delta.Items[:skipIndex]...)
+	items = append(items, skipped...)
+	items = append(items, dateRanges...)
+	for _, item := range delta.Items[skipIndex+1:] {
+		if dri, ok := item.(*DateRangeItem); ok && moved[dri.ID] {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	pl := *delta
+	pl.Items = items
+	// the source text doesn't match the items anymore
+	pl.source = nil
+
+	return &pl, nil
+}
+
+// DeltaOptions represents the parameters of a playlist delta update
+type DeltaOptions struct {
+	// SkipBoundary is the distance in seconds from the end of the playlist,
+	// segments ending before it are skipped. Zero means CAN-SKIP-UNTIL.
+	SkipBoundary float64
+	// SkipDateRanges skips the date ranges of skipped segments as well (_HLS_skip=v2)
+	SkipDateRanges bool
+	// RecentlyRemovedDateRanges lists the IDs of date ranges removed from the playlist recently,
+	// it is used only along with SkipDateRanges
+	RecentlyRemovedDateRanges []string
+}
+
+// NewDeltaPlaylist returns the playlist delta update of a full media playlist,
+// where the segments older than the skip boundary are replaced by #EXT-X-SKIP.
+// The playlist must allow skipping with CAN-SKIP-UNTIL (and CAN-SKIP-DATERANGES to skip date ranges).
+func NewDeltaPlaylist(pl *Playlist, opts DeltaOptions) (*Playlist, error) {
+	if pl.IsMaster() || pl.ServerControl == nil || pl.ServerControl.CanSkipUntil == nil {
+		return nil, ErrDeltaUpdateNotSupported
+	}
+	boundary := *pl.ServerControl.CanSkipUntil
+	if opts.SkipBoundary != 0 {
+		if opts.SkipBoundary < boundary {
+			return nil, ErrSkipBoundaryInvalid
+		}
+		boundary = opts.SkipBoundary
+	}
+	if opts.SkipDateRanges &&
+		(pl.ServerControl.CanSkipDateRanges == nil || !*pl.ServerControl.CanSkipDateRanges) {
+		return nil, ErrDeltaUpdateNotSupported
+	}
+
+	// find the item following the last skipped segment
+	end := pl.LiveEdge()
+	position := 0.0
+	skipped := 0
+	skipEnd := 0
+	for i, item := range pl.Items {
+		si, ok := item.(*SegmentItem)
+		if !ok {
+			continue
+		}
+		position += si.Duration
+		if end-position < boundary {
+			break
+		}
+		skipped++
+		skipEnd = i + 1
+	}
+
+	delta := *pl
+	delta.source = nil
+	if skipped == 0 {
+		delta.Items = append([]Item(nil), pl.Items...)
+		return &delta, nil
+	}
+
+	skip := &SkipItem{SkippedSegments: skipped}
+	if opts.SkipDateRanges {
+		skip.RecentlyRemovedDateRanges = append([]string{}, opts.RecentlyRemovedDateRanges...)
+	}
+
+	items := []Item{skip}
+	if !opts.SkipDateRanges {
+		for _, item := range pl.Items[:skipEnd] {
+			if dri, ok := item.(*DateRangeItem); ok {
+				items = append(items, dri)
+			}
+		}
+	}
+	delta.Items = append(items, pl.Items[skipEnd:]...)
+
+	if delta.Version == nil || *delta.Version < deltaUpdateVersion {
+		version := deltaUpdateVersion
+		delta.Version = &version
+	}
+
+	return &delta, nil
+}
+
+// WriteDelta writes the playlist delta update of a full media playlist to a string
+func WriteDelta(pl *Playlist, opts DeltaOptions) (string, error) {
+	delta, err := NewDeltaPlaylist(pl, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return Write(delta)
+}
@@ -0,0 +1,262 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deltaPreviousPlaylist = `#EXTM3U
+#EXT-X-VERSION:9
+#EXT-X-TARGETDURATION:4
+#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,CAN-SKIP-UNTIL=12,CAN-SKIP-DATERANGES=YES
+#EXT-X-MEDIA-SEQUENCE:10
+#EXT-X-MAP:URI="init.mp4"
+#EXT-X-DATERANGE:ID="ad-1",START-DATE="2023-01-01T00:00:00Z"
+#EXTINF:4,
+segment10.mp4
+#EXTINF:4,
+segment11.mp4
+#EXT-X-DATERANGE:ID="ad-2",START-DATE="2023-01-01T00:00:08Z"
+#EXTINF:4,
+segment12.mp4
+#EXTINF:4,
+segment13.mp4
+#EXT-X-DATERANGE:ID="ad-3",START-DATE="2023-01-01T00:00:16Z"
+#EXTINF:4,
+segment14.mp4
+`
+
+func TestApplyDelta(t *testing.T) {
+	previous, err := ReadString(deltaPreviousPlaylist)
+	require.NoError(t, err)
+
+	delta, err := ReadString(`#EXTM3U
+#EXT-X-VERSION:9
+#EXT-X-TARGETDURATION:4
+#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,CAN-SKIP-UNTIL=12,CAN-SKIP-DATERANGES=YES
+#EXT-X-MEDIA-SEQUENCE:11
+#EXT-X-SKIP:SKIPPED-SEGMENTS=3,RECENTLY-REMOVED-DATERANGES="ad-1	ad-2"
+#EXTINF:4,
+segment14.mp4
+#EXTINF:4,
+segment15.mp4
+`)
+	require.NoError(t, err)
+	assert.IsType(t, &SkipItem{}, delta.Items[0])
+
+	pl, err := ApplyDelta(previous, delta)
+	require.NoError(t, err)
+	assert.Equal(t, 11, pl.Sequence)
+	assert.Equal(t, delta.ServerControl, pl.ServerControl)
+
+	segments := pl.Segments()
+	require.Len(t, segments, 5)
+	for i, segment := range segments {
+		assert.Equal(t, fmt.Sprintf("segment%d.mp4", 11+i), segment.Segment)
+	}
+
+	require.Len(t, pl.Items, 7)
+	assert.Equal(t, `#EXT-X-MAP:URI="init.mp4"`, pl.Items[0].String())
+	assert.Equal(t, segments[0], pl.Items[1])
+	assert.Equal(t, segments[1], pl.Items[2])
+	assert.Equal(t, segments[2], pl.Items[3])
+	// ad-1 and ad-2 are removed
+	// ad-3 is not repeated in the delta, but is still active
+	assert.IsType(t, &DateRangeItem{}, pl.Items[4])
+	assert.Equal(t, "ad-3", pl.Items[4].(*DateRangeItem).ID)
+}
+
+func TestApplyDelta_DateRangesBeforeSkipped(t *testing.T) {
+	previous, err := ReadString(deltaPreviousPlaylist)
+	require.NoError(t, err)
+
+	// ad-1 precedes the skipped segments and is not removed
+	delta, err := ReadString(`#EXTM3U
+#EXT-X-VERSION:9
+#EXT-X-TARGETDURATION:4
+#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,CAN-SKIP-UNTIL=12,CAN-SKIP-DATERANGES=YES
+#EXT-X-MEDIA-SEQUENCE:11
+#EXT-X-SKIP:SKIPPED-SEGMENTS=3,RECENTLY-REMOVED-DATERANGES="ad-2"
+#EXTINF:4,
+segment14.mp4
+#EXTINF:4,
+segment15.mp4
+`)
+	require.NoError(t, err)
+
+	pl, err := ApplyDelta(previous, delta)
+	require.NoError(t, err)
+	var ids []string
+	for _, item := range pl.Items {
+		if dri, ok := item.(*DateRangeItem); ok {
+			ids = append(ids, dri.ID)
+		}
+	}
+	assert.Equal(t, []string{"ad-1", "ad-3"}, ids)
+	assert.Equal(t, 5, pl.SegmentSize())
+}
+
+func TestApplyDelta_FullPlaylist(t *testing.T) {
+	previous, err := ReadString(deltaPreviousPlaylist)
+	require.NoError(t, err)
+
+	pl, err := ApplyDelta(&Playlist{}, previous)
+	require.NoError(t, err)
+	assert.Equal(t, previous, pl)
+}
+
+func TestApplyDelta_Invalid(t *testing.T) {
+	previous, err := ReadString(deltaPreviousPlaylist)
+	require.NoError(t, err)
+
+	testCases := []string{
+		// skipped segments are older than the previous playlist
+		"#EXTM3U\n#EXT-X-MEDIA-SEQUENCE:8\n#EXT-X-SKIP:SKIPPED-SEGMENTS=3\n#EXTINF:4,\nsegment11.mp4\n",
+		// skipped segments are newer than the previous playlist
+		"#EXTM3U\n#EXT-X-MEDIA-SEQUENCE:13\n#EXT-X-SKIP:SKIPPED-SEGMENTS=3\n#EXTINF:4,\nsegment16.mp4\n",
+	}
+
+	for _, tc := range testCases {
+		delta, err := ReadString(tc)
+		require.NoError(t, err)
+
+		pl, err := ApplyDelta(previous, delta)
+		assert.Equal(t, ErrDeltaPlaylistInvalid, err)
+		assert.Nil(t, pl)
+	}
+}
+
+const deltaFullPlaylist = `#EXTM3U
+#EXT-X-VERSION:9
+#EXT-X-TARGETDURATION:4
+#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,CAN-SKIP-UNTIL=12,CAN-SKIP-DATERANGES=YES
+#EXT-X-MEDIA-SEQUENCE:11
+#EXT-X-MAP:URI="init.mp4"
+#EXTINF:4,
+segment11.mp4
+#EXT-X-DATERANGE:ID="ad-2",START-DATE="2023-01-01T00:00:08Z"
+#EXTINF:4,
+segment12.mp4
+#EXTINF:4,
+segment13.mp4
+#EXT-X-DATERANGE:ID="ad-3",START-DATE="2023-01-01T00:00:16Z"
+#EXTINF:4,
+segment14.mp4
+#EXTINF:4,
+segment15.mp4
+#EXTINF:4,
+segment16.mp4
+#EXTINF:4,
+segment17.mp4
+`
+
+func TestNewDeltaPlaylist(t *testing.T) {
+	previous, err := ReadString(deltaPreviousPlaylist)
+	require.NoError(t, err)
+	full, err := ReadString(deltaFullPlaylist)
+	require.NoError(t, err)
+	expected, err := Write(full)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		opts              DeltaOptions
+		skippedSegments   int
+		removedDateRanges []string
+		dateRanges        int
+	}{
+		// segments ending 12 seconds before the end of the playlist are skipped,
+		// date ranges of skipped segments are repeated
+		{opts: DeltaOptions{}, skippedSegments: 4, dateRanges: 2},
+		{opts: DeltaOptions{SkipBoundary: 16}, skippedSegments: 3, dateRanges: 2},
+		{
+			opts:              DeltaOptions{SkipDateRanges: true, RecentlyRemovedDateRanges: []string{"ad-1"}},
+			skippedSegments:   4,
+			removedDateRanges: []string{"ad-1"},
+			dateRanges:        0,
+		},
+	}
+
+	for _, tc := range testCases {
+		delta, err := NewDeltaPlaylist(full, tc.opts)
+		require.NoError(t, err)
+		require.IsType(t, &SkipItem{}, delta.Items[0])
+		skip := delta.Items[0].(*SkipItem)
+		assert.Equal(t, tc.skippedSegments, skip.SkippedSegments)
+		assert.Equal(t, tc.removedDateRanges, skip.RecentlyRemovedDateRanges)
+		assert.Equal(t, 7-tc.skippedSegments, delta.SegmentSize())
+
+		dateRanges := 0
+		for _, item := range delta.Items {
+			if _, ok := item.(*DateRangeItem); ok {
+				dateRanges++
+			}
+		}
+		assert.Equal(t, tc.dateRanges, dateRanges)
+
+		// the delta is written and read by a client as any other playlist
+		s, err := WriteDelta(full, tc.opts)
+		require.NoError(t, err)
+		delta, err = ReadString(s)
+		require.NoError(t, err)
+
+		pl, err := ApplyDelta(previous, delta)
+		require.NoError(t, err)
+		actual, err := Write(pl)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestNewDeltaPlaylist_Lossless(t *testing.T) {
+	previous, err := ReadString(deltaPreviousPlaylist)
+	require.NoError(t, err)
+	full, err := ReadWithOptions(strings.NewReader(deltaFullPlaylist), ReadOptions{Lossless: true})
+	require.NoError(t, err)
+
+	// the delta of a playlist read in lossless mode is written anew
+	s, err := WriteDelta(full, DeltaOptions{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(s, HeaderTag+"\n"), s)
+	delta, err := ReadString(s)
+	require.NoError(t, err)
+	require.IsType(t, &SkipItem{}, delta.Items[0])
+
+	pl, err := ApplyDelta(previous, delta)
+	require.NoError(t, err)
+	assert.Equal(t, full.SegmentSize(), pl.SegmentSize())
+}
+
+func TestNewDeltaPlaylist_LowLatency(t *testing.T) {
+	full, err := ReadFile("fixtures/llhls.m3u8")
+	require.NoError(t, err)
+
+	delta, err := NewDeltaPlaylist(full, DeltaOptions{})
+	require.NoError(t, err)
+	assertNotNilEqual(t, 9, delta.Version)
+	assert.Equal(t, 4, delta.Items[0].(*SkipItem).SkippedSegments)
+	assert.Equal(t, 3, delta.SegmentSize())
+
+	pl, err := ApplyDelta(full, delta)
+	require.NoError(t, err)
+	assert.Equal(t, full.Items, pl.Items)
+}
+
+func TestNewDeltaPlaylist_Invalid(t *testing.T) {
+	full, err := ReadString(deltaFullPlaylist)
+	require.NoError(t, err)
+
+	_, err = NewDeltaPlaylist(full, DeltaOptions{SkipBoundary: 8})
+	assert.Equal(t, ErrSkipBoundaryInvalid, err)
+
+	full.ServerControl.CanSkipDateRanges = nil
+	_, err = NewDeltaPlaylist(full, DeltaOptions{SkipDateRanges: true})
+	assert.Equal(t, ErrDeltaUpdateNotSupported, err)
+
+	full.ServerControl = nil
+	_, err = WriteDelta(full, DeltaOptions{})
+	assert.Equal(t, ErrDeltaUpdateNotSupported, err)
+}
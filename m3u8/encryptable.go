@@ -15,13 +15,24 @@ type Encryptable struct {
 	KeyFormat         *string
 	KeyFormatVersions *string
 	KeyID             *string
-	attributes        map[string]string
+	attributes        parser.AttributeList
 }
 
-// NewEncryptable takes an attributes map and returns an *Encryptable
+// NewEncryptable takes an attributes map and returns an *Encryptable,
+// unknown attributes are written sorted by name
 func NewEncryptable(attributes map[string]string) *Encryptable {
-	defer deleteKeys(
-		attributes,
+	return newEncryptable(attributes, sortedAttributes(attributes))
+}
+
+// parseEncryptable parses a text line and returns an *Encryptable,
+// unknown attributes are written in their original order
+func parseEncryptable(text string) *Encryptable {
+	list := parser.ParseAttributeList(text)
+	return newEncryptable(list.Map(), list)
+}
+
+func newEncryptable(attributes map[string]string, list parser.AttributeList) *Encryptable {
+	unknown := list.Without(
 		MethodTag,
 		URITag,
 		IVTag,
@@ -37,7 +48,7 @@ func NewEncryptable(attributes map[string]string) *Encryptable {
 		KeyFormat:         parser.PointerTo(attributes, KeyFormatTag),
 		KeyFormatVersions: parser.PointerTo(attributes, KeyFormatVersionsTag),
 		KeyID:             parser.PointerTo(attributes, KeyID),
-		attributes:        attributes,
+		attributes:        unknown,
 	}
 }
 
@@ -61,9 +72,7 @@ func (e *Encryptable) String() string {
 		slice = append(slice, fmt.Sprintf(parser.FormatString, KeyID, *e.KeyID))
 	}
 
-	for attributeKey, attribute := range e.attributes {
-		slice = append(slice, fmt.Sprintf(parser.FormatString, attributeKey, attribute))
-	}
+	slice = attributesJoin(slice, e.attributes)
 
 	return strings.Join(slice, ",")
 }
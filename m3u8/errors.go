@@ -1,6 +1,9 @@
 package m3u8
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrPlaylistInvalid represents playlist error when playlist does not start with #EXTM3U
@@ -14,4 +17,78 @@ var (
 
 	// ErrPlaylistItemInvalid represents error when a playlist item is invalid
 	ErrPlaylistItemInvalid = errors.New("invalid playlist item")
+
+	// ErrPartItemInvalid represents error when a partial segment item is invalid
+	ErrPartItemInvalid = errors.New("invalid part item")
+
+	// ErrPartInfInvalid represents error when a part information tag is invalid
+	ErrPartInfInvalid = errors.New("invalid part information")
+
+	// ErrSkipItemInvalid represents error when a skip item is invalid
+	ErrSkipItemInvalid = errors.New("invalid skip item")
+
+	// ErrDeltaPlaylistInvalid represents error when a delta update can't be applied to a previous playlist
+	ErrDeltaPlaylistInvalid = errors.New("invalid delta playlist, skipped segments are missing in previous playlist")
+
+	// ErrDeltaUpdateNotSupported represents error when a playlist doesn't allow the requested delta update
+	ErrDeltaUpdateNotSupported = errors.New("delta update is not supported by playlist server control")
+
+	// ErrSkipBoundaryInvalid represents error when a skip boundary is less than CAN-SKIP-UNTIL
+	ErrSkipBoundaryInvalid = errors.New("invalid skip boundary, must not be less than CAN-SKIP-UNTIL")
+
+	// ErrPathwayInvalid represents error when a pathway can't be cloned
+	ErrPathwayInvalid = errors.New("invalid pathway, base pathway must exist and new pathway must not")
+
+	// ErrHostInvalid represents error when a host replacing the host of URIs isn't a valid host
+	ErrHostInvalid = errors.New("invalid host")
+
+	// ErrVariableUndefined represents error when a variable is referenced but not defined
+	ErrVariableUndefined = errors.New("undefined variable")
+
+	// ErrVariableInvalid represents error when a variable definition is invalid
+	ErrVariableInvalid = errors.New("invalid variable definition")
+
+	// ErrSegmentNotFound represents error when no segment matches a position or a date
+	ErrSegmentNotFound = errors.New("segment not found")
+
+	// ErrProgramDateTimeMissing represents error when the date of a segment can't be determined
+	ErrProgramDateTimeMissing = errors.New("missing program date time")
+
+	// ErrClipRangeInvalid represents error when the end of a clip is not after its start
+	ErrClipRangeInvalid = errors.New("invalid clip range, end must be after start")
+
+	// ErrAdBreakUnmatched represents error when an ad break marker has no matching start or end
+	ErrAdBreakUnmatched = errors.New("unmatched ad break marker")
+
+	// ErrAdBreakOverlap represents error when ad breaks overlap
+	ErrAdBreakOverlap = errors.New("overlapping ad breaks")
+
+	// ErrAdBreakBoundary represents error when an ad break doesn't start or end on a segment boundary
+	ErrAdBreakBoundary = errors.New("ad break not on segment boundary")
 )
+
+// ParseError represents error of parsing a playlist line
+type ParseError struct {
+	// Line is the 1-based line number, zero if unknown
+	Line int
+	// Column is the 1-based position in the line of the value which failed to parse
+	Column int
+	// Tag is the name of the tag (e.g. #EXTINF), empty for URI lines
+	Tag string
+	// Text is the raw line
+	Text string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	tag := e.Tag
+	if tag == "" {
+		tag = "URI"
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %v: %s", e.Line, e.Column, tag, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,123 @@
+package m3u8
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+func TestParseError(t *testing.T) {
+	s := strings.Join([]string{
+		HeaderTag,
+		VersionTag + ":x",
+		"",
+		`#EXT-X-PART:URI="part.mp4",DURATION=abc`,
+		"#EXTINF:4,",
+		"segment.ts",
+	}, "\n")
+
+	pl, err := ReadString(s)
+	require.NoError(t, err)
+	require.Len(t, pl.Items, 3)
+
+	var pe *ParseError
+	require.True(t, errors.As(pl.Items[0].(*UnknownItem).Err(), &pe))
+	assert.Equal(t, 2, pe.Line)
+	assert.Equal(t, 16, pe.Column)
+	assert.Equal(t, VersionTag, pe.Tag)
+	assert.Equal(t, VersionTag+":x", pe.Text)
+
+	require.True(t, errors.As(pl.Items[1].(*UnknownItem).Err(), &pe))
+	assert.Equal(t, 4, pe.Line)
+	assert.Equal(t, 28, pe.Column)
+	assert.Equal(t, PartItemTag, pe.Tag)
+	assert.True(t, errors.Is(pe, strconv.ErrSyntax))
+	assert.Equal(t, `line 4, column 28: #EXT-X-PART: invalid DURATION attribute: strconv.ParseFloat: parsing "abc": invalid syntax: #EXT-X-PART:URI="part.mp4",DURATION=abc`, pe.Error())
+
+	assert.Nil(t, pl.Items[2].(*SegmentItem).Parts)
+}
+
+func TestParseError_URI(t *testing.T) {
+	s := strings.Join([]string{
+		HeaderTag,
+		"#EXT-X-STREAM-INF:BANDWIDTH=540",
+		`#EXT-X-PART:DURATION=1,URI="part.mp4"`,
+		"test.m3u8",
+	}, "\n")
+
+	pl, err := ReadString(s)
+	require.Nil(t, pl)
+	assert.True(t, errors.Is(err, ErrSegmentItemInvalid))
+
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 4, pe.Line)
+	assert.Equal(t, 1, pe.Column)
+	assert.Empty(t, pe.Tag)
+	assert.Equal(t, "test.m3u8", pe.Text)
+}
+
+func TestParseError_AttributeColumn(t *testing.T) {
+	testCases := []struct {
+		line   string
+		name   string
+		column int
+	}{
+		{`#EXT-X-CONTENT-STEERING:PATHWAY-ID="CDN-A",ID=1`, "ID", 44},
+		{`#EXT-X-DATERANGE:ID="a",CLASS="x,ID=1",DURATION=x`, "DURATION", 40},
+		{`#EXT-X-DATERANGE:CLASS="x,ID=1",ID="a"`, "ID", 33},
+		{`#EXT-X-DATERANGE:ID="a"`, "ID", 18},
+		// missing attribute: position of the tag value
+		{`#EXT-X-DATERANGE:X-ID="a"`, "ID", 18},
+	}
+
+	for _, tc := range testCases {
+		err := &parser.AttributeError{Name: tc.name, Err: strconv.ErrSyntax}
+		assert.Equal(t, tc.column, errorColumn(tc.line, err), tc.line)
+	}
+}
+
+func TestReadOptions(t *testing.T) {
+	s := strings.Join([]string{
+		HeaderTag,
+		VersionTag + ":x",
+		"#EXT-X-UNKNOWN-TAG:1",
+		PlaybackStartTag,
+		"#EXTINF:4,",
+		"segment.ts",
+	}, "\n")
+
+	// lenient mode keeps invalid tags as unknown items and reports them as warnings
+	pl, err := ReadWithOptions(strings.NewReader(s), ReadOptions{})
+	require.NoError(t, err)
+	require.Len(t, pl.Items, 4)
+	warnings := pl.Warnings()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, pl.Items[0].(*UnknownItem).Err(), warnings[0])
+	assert.Equal(t, pl.Items[2].(*UnknownItem).Err(), warnings[1])
+	assert.Nil(t, pl.Items[1].(*UnknownItem).Err())
+
+	d := NewDecoder(strings.NewReader(s))
+	_, err = d.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, warnings, d.Warnings())
+
+	// strict mode fails on the first invalid tag
+	pl, err = ReadWithOptions(strings.NewReader(s), ReadOptions{Strict: true})
+	assert.Nil(t, pl)
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 2, pe.Line)
+	assert.Equal(t, VersionTag, pe.Tag)
+
+	// unknown tags are not errors in strict mode
+	pl, err = ReadWithOptions(strings.NewReader("#EXTM3U\n#EXT-X-UNKNOWN-TAG:1\n"), ReadOptions{Strict: true})
+	require.NoError(t, err)
+	assert.Empty(t, pl.Warnings())
+}
@@ -27,7 +27,7 @@ type ImageStreamItem struct {
 	FrameRate        *float64
 	Resolution       *parser.Resolution
 	StableVariantID  *string
-	attributes       map[string]string
+	attributes       parser.AttributeList
 }
 
 // NewImageStreamItem parses a text line and returns a *ImageStreamItem
@@ -42,7 +42,7 @@ func NewImageStreamItem(text string) *ImageStreamItem {
 		frameRate = nil
 	}
 
-	defer deleteKeys(attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		ResolutionTag,
 		AverageBandwidthTag,
 		FrameRateTag,
@@ -72,7 +72,7 @@ func NewImageStreamItem(text string) *ImageStreamItem {
 		Name:             parser.PointerTo(attributes, NameTag),
 		Resolution:       resolution,
 		StableVariantID:  parser.PointerTo(attributes, StableVariantIDTag),
-		attributes:       attributes,
+		attributes:       unknown,
 	}
 }
 
@@ -122,7 +122,7 @@ func (i *ImageStreamItem) String() string {
 
 	slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, URITag, i.URI))
 
-	slice = attributesJoinMap(slice, i.attributes)
+	slice = attributesJoin(slice, i.attributes)
 	attributesString := strings.Join(slice, ",")
 
 	return fmt.Sprintf("%s:%s", ImageStreamItemTag, attributesString)
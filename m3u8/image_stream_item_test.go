@@ -35,8 +35,10 @@ func TestNewImageStreamItem(t *testing.T) {
 
 	item := NewImageStreamItem(line)
 	require.Len(t, item.attributes, 2)
-	require.Equal(t, "123", item.attributes["RANDOM-ATTRIBUTE"])
-	require.Equal(t, "\"123\"", item.attributes["RANDOM-ATTRIBUTE2"])
+	require.Equal(t, parser.AttributeList{
+		{Name: "RANDOM-ATTRIBUTE", Value: "123"},
+		{Name: "RANDOM-ATTRIBUTE2", Value: "\"123\""},
+	}, item.attributes)
 
 	require.Equal(t, 540, item.Bandwidth)
 	require.Equal(t, "test.url", item.URI)
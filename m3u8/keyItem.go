@@ -2,8 +2,6 @@ package m3u8
 
 import (
 	"fmt"
-
-	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
 )
 
 // KeyItem represents a set of EXT-X-KEY attributes
@@ -13,10 +11,8 @@ type KeyItem struct {
 
 // NewKeyItem parses a text line and returns a *KeyItem
 func NewKeyItem(text string) *KeyItem {
-	attributes := parser.ParseAttributes(text)
-
 	return &KeyItem{
-		Encryptable: NewEncryptable(attributes),
+		Encryptable: parseEncryptable(text),
 	}
 }
 
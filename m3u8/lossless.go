@@ -0,0 +1,303 @@
+package m3u8
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+)
+
+// lineKind represents the role of a source line
+type lineKind int
+
+const (
+	// textLine is written as is (blank lines, lines not producing anything)
+	textLine lineKind = iota
+	// startLine is the #EXTM3U line
+	startLine
+	// headerTagLine is a playlist tag written by the header (see headerLines)
+	headerTagLine
+	// footerLine is the #EXT-X-ENDLIST line
+	footerLine
+	// itemLine is a line of an item
+	itemLine
+)
+
+// sourceLine represents a line of the text a playlist was read from
+type sourceLine struct {
+	text string
+	kind lineKind
+	tag  string
+	item itemKey
+}
+
+// itemKey identifies an item of a playlist: by pointer,
+// or by type and occurrence for the items which can't be told apart (see isComparable)
+type itemKey struct {
+	item Item
+	t    reflect.Type
+	n    int
+}
+
+// itemKeys counts the occurrences of the items without identity
+type itemKeys map[reflect.Type]int
+
+func (k itemKeys) key(item Item) itemKey {
+	if isComparable(item) {
+		return itemKey{item: item}
+	}
+	t := reflect.TypeOf(item)
+	k[t]++
+
+	return itemKey{t: t, n: k[t]}
+}
+
+// source keeps the text a playlist was read from in lossless mode,
+// so the lines of the unchanged items can be written back verbatim
+type source struct {
+	lines []sourceLine
+	// pending holds the lines of the item being read (#EXTINF, #EXT-X-BYTERANGE etc.)
+	pending      []int
+	finalNewline bool
+	keys         itemKeys
+	// header and items hold the text of the header tags and the items as written after reading
+	header  map[string]string
+	items   map[itemKey]string
+	anchors map[itemKey]int
+	tags    map[string]bool
+}
+
+// scanLines is a bufio.SplitFunc splitting lines like bufio.ScanLines,
+// but keeping carriage returns, and remembering if the text ends with a new line
+func (s *source) scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		s.finalNewline = true
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		s.finalNewline = false
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// record adds a source line, appended holds the items the line added to the playlist
+func (s *source) record(text, value string, appended []Item) {
+	line := sourceLine{text: text}
+
+	switch {
+	case value == "":
+	case value == HeaderTag:
+		line.kind = startLine
+	case len(appended) > 0:
+		if s.keys == nil {
+			s.keys = make(itemKeys)
+		}
+		line.kind = itemLine
+		for _, item := range appended {
+			line.item = s.keys.key(item)
+		}
+		switch appended[len(appended)-1].(type) {
+		case *SegmentItem, *PlaylistItem:
+			for _, i := range s.pending {
+				s.lines[i].kind = itemLine
+				s.lines[i].item = line.item
+			}
+			s.pending = nil
+		}
+	case matchTag(value, FooterTag):
+		line.kind = footerLine
+	default:
+		if tag, ok := matchHeaderTag(value); ok {
+			line.kind = headerTagLine
+			line.tag = tag
+			break
+		}
+		s.pending = append(s.pending, len(s.lines))
+	}
+
+	s.lines = append(s.lines, line)
+}
+
+// snapshot records the text of the header tags and the items of a playlist once read
+func (s *source) snapshot(pl *Playlist) {
+	s.pending = nil
+
+	// parts are read as items before being attached to their segment
+	parents := make(map[itemKey]itemKey)
+	for _, item := range pl.Items {
+		if si, ok := item.(*SegmentItem); ok {
+			for _, part := range si.Parts {
+				parents[itemKey{item: part}] = itemKey{item: si}
+			}
+		}
+	}
+
+	s.header = make(map[string]string)
+	for _, line := range headerLines(pl) {
+		s.header[line.tag] = line.text
+	}
+
+	s.items = make(map[itemKey]string)
+	keys := make(itemKeys)
+	for _, item := range pl.Items {
+		s.items[keys.key(item)] = item.String()
+	}
+
+	s.anchors = make(map[itemKey]int)
+	s.tags = make(map[string]bool)
+	for i := range s.lines {
+		line := &s.lines[i]
+		switch line.kind {
+		case headerTagLine:
+			s.tags[line.tag] = true
+		case itemLine:
+			if parent, ok := parents[line.item]; ok {
+				line.item = parent
+			}
+			s.anchors[line.item] = i
+		}
+	}
+}
+
+// write writes a playlist keeping the source lines of the unchanged items and header tags,
+// changed items are written at the position of their last line, new items after the item preceding them
+func (s *source) write(sb *strings.Builder, pl *Playlist) {
+	header := make(map[string]string)
+	for _, line := range headerLines(pl) {
+		header[line.tag] = line.text
+	}
+	footer := !pl.IsLive() && !pl.IsMaster()
+
+	// items keeping their relative order are written in place, the others as new ones
+	keys := make(itemKeys)
+	itemsKeys := make([]itemKey, len(pl.Items))
+	inPlace := make(map[itemKey]Item)
+	changed := make(map[itemKey]bool)
+	last := -1
+	for i, item := range pl.Items {
+		key := keys.key(item)
+		itemsKeys[i] = key
+		anchor, ok := s.anchors[key]
+		text, read := s.items[key]
+		if !ok || !read || anchor <= last {
+			continue
+		}
+		inPlace[key] = item
+		changed[key] = item.String() != text
+		last = anchor
+	}
+
+	footerWritten := false
+	writeLine := func(text string) {
+		sb.WriteString(text)
+		sb.WriteRune('\n')
+	}
+
+	cursor := 0
+	flush := func(to int) {
+		for ; cursor <= to && cursor < len(s.lines); cursor++ {
+			line := s.lines[cursor]
+			switch line.kind {
+			case startLine:
+				writeLine(line.text)
+				// playlist tags missing from the source are written only once set or changed
+				for _, hl := range headerLines(pl) {
+					if !s.tags[hl.tag] && hl.text != s.header[hl.tag] {
+						writeLine(hl.text)
+					}
+				}
+			case headerTagLine:
+				text, ok := header[line.tag]
+				if !ok {
+					continue
+				}
+				if text == s.header[line.tag] {
+					text = line.text
+				}
+				writeLine(text)
+			case footerLine:
+				if footer {
+					writeLine(line.text)
+					footerWritten = true
+				}
+			case itemLine:
+				item, ok := inPlace[line.item]
+				if !ok {
+					continue
+				}
+				if !changed[line.item] {
+					writeLine(line.text)
+				} else if s.anchors[line.item] == cursor {
+					writeLine(item.String())
+				}
+			default:
+				writeLine(line.text)
+			}
+		}
+	}
+
+	// #EXTM3U and the lines preceding the first item (header tags etc.) come before any new item
+	first := 0
+	for first < len(s.lines) && s.lines[first].kind != itemLine {
+		first++
+	}
+	flush(first - 1)
+
+	for i, item := range pl.Items {
+		if _, ok := inPlace[itemsKeys[i]]; ok {
+			flush(s.anchors[itemsKeys[i]])
+			continue
+		}
+		writeLine(item.String())
+	}
+	flush(len(s.lines))
+
+	if footer && !footerWritten {
+		writeLine(FooterTag)
+	}
+	if !s.finalNewline {
+		text := strings.TrimSuffix(sb.String(), "\n")
+		sb.Reset()
+		sb.WriteString(text)
+	}
+}
+
+// matchHeaderTag returns the playlist tag of a line written by the header
+func matchHeaderTag(line string) (string, bool) {
+	for _, tag := range []string{
+		PlaylistTypeTag,
+		VersionTag,
+		IndependentSegmentsTag,
+		IFramesOnlyTag,
+		MediaSequenceTag,
+		DiscontinuitySequenceTag,
+		CacheTag,
+		TargetDurationTag,
+		ServerControlTag,
+		PartInfTag,
+	} {
+		if matchTag(line, tag) {
+			return tag, true
+		}
+	}
+
+	return "", false
+}
+
+// isComparable reports if an item can be used as a map key identifying it,
+// pointers to zero-size values (e.g. *DiscontinuityItem) may be equal so they can't
+func isComparable(item Item) bool {
+	if item == nil {
+		return false
+	}
+	t := reflect.TypeOf(item)
+	if t.Kind() == reflect.Ptr && t.Elem().Size() == 0 {
+		return false
+	}
+
+	return t.Comparable()
+}
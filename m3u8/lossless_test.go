@@ -0,0 +1,160 @@
+package m3u8
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readLossless(t *testing.T, text string) *Playlist {
+	pl, err := ReadWithOptions(strings.NewReader(text), ReadOptions{Lossless: true})
+	require.NoError(t, err)
+	return pl
+}
+
+func TestLossless_Fixtures(t *testing.T) {
+	files, err := filepath.Glob("fixtures/*.m3u8")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		f, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		pl, err := ReadWithOptions(strings.NewReader(string(f)), ReadOptions{Lossless: true})
+		if err != nil {
+			// fixtures the reader rejects have nothing to write back
+			continue
+		}
+		if !pl.IsValid() {
+			continue
+		}
+		out, err := Write(pl)
+		require.NoError(t, err, file)
+		assert.Equal(t, string(f), out, file)
+	}
+}
+
+func TestLossless_Layout(t *testing.T) {
+	s := strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-VERSION:3",
+		"",
+		"#EXT-X-MEDIA-SEQUENCE:0",
+		"#EXTINF:10.000,",
+		"#EXT-X-BYTERANGE:100@0",
+		"segment0.ts",
+		"",
+		"#EXTINF:9.5000,",
+		"segment1.ts",
+		"#EXT-X-ENDLIST",
+	}, "\r\n")
+
+	pl := readLossless(t, s)
+	out, err := Write(pl)
+	require.NoError(t, err)
+	assert.Equal(t, s, out)
+
+	// canonical mode reorders and reformats
+	canonical, err := Read(strings.NewReader(s))
+	require.NoError(t, err)
+	out, err = Write(canonical)
+	require.NoError(t, err)
+	assert.NotEqual(t, s, out)
+}
+
+func TestLossless_Changes(t *testing.T) {
+	s := strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-MEDIA-SEQUENCE:5",
+		"#EXTINF:10.000,",
+		"segment0.ts",
+		"",
+		"#EXTINF:10.000,",
+		`#EXT-X-DATERANGE:ID="ad",START-DATE="2020-01-01T00:00:00Z"`,
+		"segment1.ts",
+		"#EXTINF:10.000,",
+		"segment2.ts",
+		"",
+	}, "\n")
+
+	pl := readLossless(t, s)
+	require.Len(t, pl.Items, 4)
+
+	// modified segment, playlist tag and date range
+	pl.Items[2].(*SegmentItem).Segment = "changed1.ts"
+	pl.Sequence = 6
+	pl.Items[1].(*DateRangeItem).ClientAttributes["X-COM"] = `"1"`
+	// removed segment, new item and playlist tag
+	di, _ := NewDiscontinuityItem()
+	pl.Items = append(pl.Items[:3], di)
+	version := 4
+	pl.Version = &version
+	pl.Live = false
+
+	out, err := Write(pl)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-VERSION:4",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-MEDIA-SEQUENCE:6",
+		"#EXTINF:10.000,",
+		"segment0.ts",
+		"",
+		`#EXT-X-DATERANGE:ID="ad",START-DATE="2020-01-01T00:00:00Z",X-COM="1"`,
+		"#EXTINF:10,",
+		"changed1.ts",
+		"#EXT-X-DISCONTINUITY",
+		"#EXT-X-ENDLIST",
+		"",
+	}, "\n"), out)
+}
+
+func TestLossless_Parts(t *testing.T) {
+	f, err := os.ReadFile("fixtures/llhls.m3u8")
+	require.NoError(t, err)
+
+	pl := readLossless(t, string(f))
+	segments := pl.Segments()
+	segments[len(segments)-1].Parts[0].Independent = nil
+
+	out, err := Write(pl)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Count(string(f), "\n"), strings.Count(out, "\n"))
+	assert.Equal(t, strings.Count(string(f), PartItemTag+":"), strings.Count(out, PartItemTag+":"))
+	assert.Equal(t, strings.Count(string(f), "INDEPENDENT=YES")-1, strings.Count(out, "INDEPENDENT=YES"))
+}
+
+func TestLossless_InsertFirst(t *testing.T) {
+	s := strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXTINF:10.000,",
+		"segment0.ts",
+		"#EXT-X-ENDLIST",
+		"",
+	}, "\n")
+
+	pl := readLossless(t, s)
+	di, _ := NewDiscontinuityItem()
+	pl.Items = append([]Item{di}, pl.Items...)
+
+	out, err := Write(pl)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-DISCONTINUITY",
+		"#EXTINF:10.000,",
+		"segment0.ts",
+		"#EXT-X-ENDLIST",
+		"",
+	}, "\n"), out)
+}
@@ -12,7 +12,7 @@ import (
 type MapItem struct {
 	URI        string
 	ByteRange  *ByteRange
-	attributes map[string]string
+	attributes parser.AttributeList
 }
 
 // NewMapItem parses a text line and returns a *MapItem
@@ -20,8 +20,7 @@ func NewMapItem(text string) *MapItem {
 	attributes := parser.ParseAttributes(text)
 	br, _ := NewByteRange(parser.SanitizeAttributeValue(attributes[ByteRangeTag]))
 
-	defer deleteKeys(
-		attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		ByteRangeTag,
 		URITag,
 	)
@@ -29,7 +28,7 @@ func NewMapItem(text string) *MapItem {
 	return &MapItem{
 		URI:        parser.SanitizeAttributeValue(attributes[URITag]),
 		ByteRange:  br,
-		attributes: attributes,
+		attributes: unknown,
 	}
 }
 
@@ -41,9 +40,7 @@ func (mi *MapItem) String() string {
 		attributes = append(attributes, fmt.Sprintf(parser.QuotedFormatString, ByteRangeTag, mi.ByteRange))
 	}
 
-	for attributeKey, attribute := range mi.attributes {
-		attributes = append(attributes, fmt.Sprintf(parser.FormatString, attributeKey, attribute))
-	}
+	attributes = attributesJoin(attributes, mi.attributes)
 
 	return fmt.Sprintf(`%s:%s`, MapItemTag, strings.Join(attributes, ","))
 }
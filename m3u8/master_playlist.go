@@ -0,0 +1,195 @@
+package m3u8
+
+// MasterPlaylist represents a master (multivariant) playlist
+type MasterPlaylist struct {
+	Version             *int
+	IndependentSegments bool
+	// Variants holds the #EXT-X-STREAM-INF and #EXT-X-I-FRAME-STREAM-INF playlists in order
+	Variants []*PlaylistItem
+	// Renditions holds the #EXT-X-MEDIA renditions in order
+	Renditions      []*MediaItem
+	ImageStreams    []*ImageStreamItem
+	SessionData     []*SessionDataItem
+	SessionKeys     []*SessionKeyItem
+	ContentSteering *ContentSteeringItem
+	Defines         []*DefineItem
+	Start           *PlaybackStart
+	// Items holds the other items (unknown tags and repeated #EXT-X-START
+	// or #EXT-X-CONTENT-STEERING tags included) in order
+	Items []Item
+	// layout holds the group of every item of the playlist read, in order
+	layout []masterGroup
+	source *source
+}
+
+// masterGroup represents the field of a MasterPlaylist holding an item
+type masterGroup int
+
+// groups in the order new items are written
+const (
+	definesGroup masterGroup = iota
+	startGroup
+	sessionDataGroup
+	sessionKeysGroup
+	contentSteeringGroup
+	renditionsGroup
+	variantsGroup
+	imageStreamsGroup
+	itemsGroup
+	groupCount
+)
+
+// NewMasterPlaylist returns the *MasterPlaylist of a master playlist
+func NewMasterPlaylist(pl *Playlist) (*MasterPlaylist, error) {
+	if !pl.IsValid() || !pl.IsMaster() {
+		return nil, ErrPlaylistInvalidType
+	}
+
+	mp := &MasterPlaylist{
+		Version:             pl.Version,
+		IndependentSegments: pl.IndependentSegments,
+		layout:              make([]masterGroup, 0, len(pl.Items)),
+		source:              pl.source,
+	}
+	for _, item := range pl.Items {
+		group := itemsGroup
+		switch it := item.(type) {
+		case *PlaylistItem:
+			mp.Variants = append(mp.Variants, it)
+			group = variantsGroup
+		case *MediaItem:
+			mp.Renditions = append(mp.Renditions, it)
+			group = renditionsGroup
+		case *ImageStreamItem:
+			mp.ImageStreams = append(mp.ImageStreams, it)
+			group = imageStreamsGroup
+		case *SessionDataItem:
+			mp.SessionData = append(mp.SessionData, it)
+			group = sessionDataGroup
+		case *SessionKeyItem:
+			mp.SessionKeys = append(mp.SessionKeys, it)
+			group = sessionKeysGroup
+		case *ContentSteeringItem:
+			if mp.ContentSteering == nil {
+				mp.ContentSteering = it
+				group = contentSteeringGroup
+			} else {
+				mp.Items = append(mp.Items, it)
+			}
+		case *DefineItem:
+			mp.Defines = append(mp.Defines, it)
+			group = definesGroup
+		case *PlaybackStart:
+			if mp.Start == nil {
+				mp.Start = it
+				group = startGroup
+			} else {
+				mp.Items = append(mp.Items, it)
+			}
+		default:
+			mp.Items = append(mp.Items, it)
+		}
+		mp.layout = append(mp.layout, group)
+	}
+
+	return mp, nil
+}
+
+// groups returns the items of every field of the master playlist
+func (mp *MasterPlaylist) groups() [groupCount][]Item {
+	var groups [groupCount][]Item
+	for _, item := range mp.Defines {
+		groups[definesGroup] = append(groups[definesGroup], item)
+	}
+	if mp.Start != nil {
+		groups[startGroup] = []Item{mp.Start}
+	}
+	for _, item := range mp.SessionData {
+		groups[sessionDataGroup] = append(groups[sessionDataGroup], item)
+	}
+	for _, item := range mp.SessionKeys {
+		groups[sessionKeysGroup] = append(groups[sessionKeysGroup], item)
+	}
+	if mp.ContentSteering != nil {
+		groups[contentSteeringGroup] = []Item{mp.ContentSteering}
+	}
+	for _, item := range mp.Renditions {
+		groups[renditionsGroup] = append(groups[renditionsGroup], item)
+	}
+	for _, item := range mp.Variants {
+		groups[variantsGroup] = append(groups[variantsGroup], item)
+	}
+	for _, item := range mp.ImageStreams {
+		groups[imageStreamsGroup] = append(groups[imageStreamsGroup], item)
+	}
+	groups[itemsGroup] = append(groups[itemsGroup], mp.Items...)
+
+	return groups
+}
+
+// Playlist returns the master playlist as a *Playlist. The items keep the order of the playlist read:
+// the items of each field take the positions of the ones read, added items follow the last one of their field.
+// Items of a field missing from the playlist read are written in the order:
+// definitions, start, session data and keys, content steering, renditions, variants,
+// image streams and the other items
+func (mp *MasterPlaylist) Playlist() *Playlist {
+	master := true
+	pl := NewPlaylist()
+	pl.Master = &master
+	pl.Live = false
+	pl.Version = mp.Version
+	pl.IndependentSegments = mp.IndependentSegments
+	pl.source = mp.source
+
+	groups := mp.groups()
+
+	// last position of each field in the playlist read, -1 if missing
+	var last [groupCount]int
+	for group := range last {
+		last[group] = -1
+	}
+	for i, group := range mp.layout {
+		last[group] = i
+	}
+
+	// the items of missing fields are written before the first item of a following field
+	before := make(map[int][]Item)
+	var tail []Item
+	for group := masterGroup(0); group < groupCount; group++ {
+		if last[group] >= 0 {
+			continue
+		}
+		next := -1
+		for i, g := range mp.layout {
+			if g > group {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			tail = append(tail, groups[group]...)
+		} else {
+			before[next] = append(before[next], groups[group]...)
+		}
+	}
+
+	var used [groupCount]int
+	for i, group := range mp.layout {
+		pl.Items = append(pl.Items, before[i]...)
+		if used[group] < len(groups[group]) {
+			pl.Items = append(pl.Items, groups[group][used[group]])
+			used[group]++
+		}
+		if i == last[group] {
+			pl.Items = append(pl.Items, groups[group][used[group]:]...)
+			used[group] = len(groups[group])
+		}
+	}
+	pl.Items = append(pl.Items, tail...)
+
+	return pl
+}
+
+func (mp *MasterPlaylist) String() string {
+	return mp.Playlist().String()
+}
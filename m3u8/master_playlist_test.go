@@ -0,0 +1,135 @@
+package m3u8
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_Master(t *testing.T) {
+	f, err := os.Open("fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+	defer f.Close()
+
+	decoded, err := Decode(f)
+	require.NoError(t, err)
+	mp, ok := decoded.(*MasterPlaylist)
+	require.True(t, ok)
+
+	assert.Equal(t, 10, *mp.Version)
+	assert.True(t, mp.IndependentSegments)
+	require.Len(t, mp.Variants, 4)
+	assert.Equal(t, "https://cdn-a.example.com/video/low.m3u8", mp.Variants[0].URI)
+	require.Len(t, mp.Renditions, 2)
+	require.NotNil(t, mp.ContentSteering)
+	assert.Equal(t, "https://steering.example.com/steering.json", mp.ContentSteering.ServerURI)
+	assert.Empty(t, mp.Items)
+
+	pl, err := ReadFile("fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+	expected, err := Write(pl)
+	require.NoError(t, err)
+	assert.Equal(t, expected, mp.String())
+	assert.True(t, mp.Playlist().IsMaster())
+}
+
+func TestNewMasterPlaylist(t *testing.T) {
+	pl, err := ReadString(strings.Join([]string{
+		"#EXTM3U",
+		`#EXT-X-DEFINE:NAME="host",VALUE="example.com"`,
+		`#EXT-X-SESSION-DATA:DATA-ID="com.example.title",VALUE="title"`,
+		`#EXT-X-SESSION-KEY:METHOD=AES-128,URI="https://example.com/key"`,
+		"#EXT-X-START:TIME-OFFSET=10",
+		"#EXT-X-UNKNOWN-TAG",
+		"#EXT-X-STREAM-INF:BANDWIDTH=1280000",
+		"low.m3u8",
+		`#EXT-X-I-FRAME-STREAM-INF:BANDWIDTH=86000,URI="low/iframe.m3u8"`,
+	}, "\n"))
+	require.NoError(t, err)
+
+	mp, err := NewMasterPlaylist(pl)
+	require.NoError(t, err)
+	require.Len(t, mp.Defines, 1)
+	require.Len(t, mp.SessionData, 1)
+	require.Len(t, mp.SessionKeys, 1)
+	require.NotNil(t, mp.Start)
+	require.Len(t, mp.Variants, 2)
+	assert.True(t, mp.Variants[1].IFrame)
+	require.Len(t, mp.Items, 1)
+	assert.IsType(t, &UnknownItem{}, mp.Items[0])
+	assert.Len(t, mp.Playlist().Items, len(pl.Items))
+
+	media, err := ReadFile("fixtures/playlist.m3u8")
+	require.NoError(t, err)
+	_, err = NewMasterPlaylist(media)
+	assert.Equal(t, ErrPlaylistInvalidType, err)
+}
+
+func TestMasterPlaylist_Playlist_Order(t *testing.T) {
+	s := strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-START:TIME-OFFSET=10",
+		`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="en",URI="en.m3u8"`,
+		"#EXT-X-UNKNOWN-TAG",
+		"#EXT-X-STREAM-INF:BANDWIDTH=1280000,AUDIO=\"aac\"",
+		"low.m3u8",
+		"#EXT-X-START:TIME-OFFSET=20",
+		`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="fr",URI="fr.m3u8"`,
+		"#EXT-X-STREAM-INF:BANDWIDTH=2560000,AUDIO=\"aac\"",
+		"high.m3u8",
+		"",
+	}, "\n")
+	pl, err := ReadString(s)
+	require.NoError(t, err)
+	expected, err := Write(pl)
+	require.NoError(t, err)
+
+	decoded, err := Decode(strings.NewReader(s))
+	require.NoError(t, err)
+	mp := decoded.(*MasterPlaylist)
+	assert.Equal(t, 10.0, mp.Start.TimeOffset)
+	require.Len(t, mp.Items, 2)
+	assert.Equal(t, expected, mp.String())
+
+	// added items follow the last item of their field, new fields are written in the default order
+	mp.Renditions = append(mp.Renditions, &MediaItem{Type: "AUDIO", GroupID: "aac", Name: "de"})
+	mp.Variants = mp.Variants[1:]
+	mp.SessionData = []*SessionDataItem{{DataID: "com.example.title", Value: pointer.ToString("title")}}
+	items := mp.Playlist().Items
+	require.Len(t, items, len(pl.Items)+1)
+	assert.Equal(t, pl.Items[0], items[0])
+	assert.Equal(t, mp.SessionData[0], items[1])
+	assert.Equal(t, mp.Renditions[0], items[2])
+	assert.Equal(t, pl.Items[2], items[3])
+	assert.Equal(t, mp.Variants[0], items[4])
+	assert.Equal(t, pl.Items[4], items[5])
+	assert.Equal(t, mp.Renditions[1], items[6])
+	assert.Equal(t, mp.Renditions[2], items[7])
+}
+
+func TestDecodeWithOptions(t *testing.T) {
+	s := strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-STREAM-INF:BANDWIDTH=1280000",
+		"low.m3u8",
+		"",
+		"#EXT-X-STREAM-INF:BANDWIDTH=2560000",
+		"high.m3u8",
+	}, "\n")
+
+	decoded, err := DecodeWithOptions(strings.NewReader(s), ReadOptions{Lossless: true})
+	require.NoError(t, err)
+	mp := decoded.(*MasterPlaylist)
+	out, err := Write(mp.Playlist())
+	require.NoError(t, err)
+	assert.Equal(t, s, out)
+
+	_, err = DecodeWithOptions(strings.NewReader("#EXTM3U\n#EXT-X-VERSION:x\n#EXT-X-STREAM-INF:BANDWIDTH=1\nlow.m3u8\n"), ReadOptions{Strict: true})
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+}
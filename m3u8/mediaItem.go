@@ -22,13 +22,14 @@ type MediaItem struct {
 	Characteristics   *string
 	Channels          *string
 	StableRenditionId *string
-	attributes        map[string]string
+	PathwayID         *string
+	attributes        parser.AttributeList
 }
 
 // NewMediaItem parses a text line and returns a *MediaItem
 func NewMediaItem(text string) *MediaItem {
 	attributes := parser.ParseAttributes(text)
-	defer deleteKeys(attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		TypeTag,
 		GroupIDTag,
 		NameTag,
@@ -42,6 +43,7 @@ func NewMediaItem(text string) *MediaItem {
 		CharacteristicsTag,
 		ChannelsTag,
 		StableRenditionIDTag,
+		PathwayIDTag,
 	)
 
 	return &MediaItem{
@@ -58,7 +60,8 @@ func NewMediaItem(text string) *MediaItem {
 		Characteristics:   parser.PointerTo(attributes, CharacteristicsTag),
 		Channels:          parser.PointerTo(attributes, ChannelsTag),
 		StableRenditionId: parser.PointerTo(attributes, StableRenditionIDTag),
-		attributes:        attributes,
+		PathwayID:         parser.PointerTo(attributes, PathwayIDTag),
+		attributes:        unknown,
 	}
 }
 
@@ -99,9 +102,10 @@ func (mi *MediaItem) String() string {
 	if mi.StableRenditionId != nil {
 		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, StableRenditionIDTag, *mi.StableRenditionId))
 	}
-	for attributeKey, attribute := range mi.attributes {
-		slice = append(slice, fmt.Sprintf(parser.FormatString, attributeKey, attribute))
+	if mi.PathwayID != nil {
+		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, PathwayIDTag, *mi.PathwayID))
 	}
+	slice = attributesJoin(slice, mi.attributes)
 
 	return fmt.Sprintf("%s:%s", MediaItemTag, strings.Join(slice, ","))
 }
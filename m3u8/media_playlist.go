@@ -0,0 +1,81 @@
+package m3u8
+
+// MediaPlaylist represents a media playlist
+type MediaPlaylist struct {
+	Version               *int
+	Cache                 *bool
+	TargetDuration        int
+	MediaSequence         int
+	DiscontinuitySequence *int
+	Type                  *string
+	IFramesOnly           bool
+	IndependentSegments   bool
+	// EndList is set when the playlist ends with #EXT-X-ENDLIST (no more segments will be added)
+	EndList       bool
+	PartInf       *PartInf
+	ServerControl *ServerControl
+	// Items holds the segments along with the tags applying to them (keys, maps, date ranges etc.) in order
+	Items  []Item
+	source *source
+}
+
+// NewMediaPlaylist returns the *MediaPlaylist of a media playlist
+func NewMediaPlaylist(pl *Playlist) (*MediaPlaylist, error) {
+	if !pl.IsValid() || pl.IsMaster() {
+		return nil, ErrPlaylistInvalidType
+	}
+
+	return &MediaPlaylist{
+		Version:               pl.Version,
+		Cache:                 pl.Cache,
+		TargetDuration:        pl.Target,
+		MediaSequence:         pl.Sequence,
+		DiscontinuitySequence: pl.DiscontinuitySequence,
+		Type:                  pl.Type,
+		IFramesOnly:           pl.IFramesOnly,
+		IndependentSegments:   pl.IndependentSegments,
+		EndList:               !pl.IsLive(),
+		PartInf:               pl.PartInf,
+		ServerControl:         pl.ServerControl,
+		Items:                 append([]Item(nil), pl.Items...),
+		source:                pl.source,
+	}, nil
+}
+
+// Playlist returns the media playlist as a *Playlist
+func (mp *MediaPlaylist) Playlist() *Playlist {
+	master := false
+
+	return &Playlist{
+		Items:                 append([]Item(nil), mp.Items...),
+		Version:               mp.Version,
+		Cache:                 mp.Cache,
+		Target:                mp.TargetDuration,
+		Sequence:              mp.MediaSequence,
+		DiscontinuitySequence: mp.DiscontinuitySequence,
+		Type:                  mp.Type,
+		IFramesOnly:           mp.IFramesOnly,
+		IndependentSegments:   mp.IndependentSegments,
+		Live:                  !mp.EndList,
+		Master:                &master,
+		PartInf:               mp.PartInf,
+		ServerControl:         mp.ServerControl,
+		source:                mp.source,
+	}
+}
+
+// Segments returns the segments of the media playlist
+func (mp *MediaPlaylist) Segments() []*SegmentItem {
+	var segments []*SegmentItem
+	for _, item := range mp.Items {
+		if si, ok := item.(*SegmentItem); ok {
+			segments = append(segments, si)
+		}
+	}
+
+	return segments
+}
+
+func (mp *MediaPlaylist) String() string {
+	return mp.Playlist().String()
+}
@@ -0,0 +1,61 @@
+package m3u8
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_Media(t *testing.T) {
+	f, err := os.Open("fixtures/llhls.m3u8")
+	require.NoError(t, err)
+	defer f.Close()
+
+	decoded, err := Decode(f)
+	require.NoError(t, err)
+	mp, ok := decoded.(*MediaPlaylist)
+	require.True(t, ok)
+
+	pl, err := ReadFile("fixtures/llhls.m3u8")
+	require.NoError(t, err)
+
+	assert.Equal(t, pl.Target, mp.TargetDuration)
+	assert.Equal(t, 266, mp.MediaSequence)
+	assert.False(t, mp.EndList)
+	assert.NotNil(t, mp.PartInf)
+	assert.NotNil(t, mp.ServerControl)
+	assert.Equal(t, pl.Segments(), mp.Segments())
+
+	expected, err := Write(pl)
+	require.NoError(t, err)
+	assert.Equal(t, expected, mp.String())
+	assert.False(t, mp.Playlist().IsMaster())
+}
+
+func TestNewMediaPlaylist(t *testing.T) {
+	pl, err := ReadFile("fixtures/playlist.m3u8")
+	require.NoError(t, err)
+
+	mp, err := NewMediaPlaylist(pl)
+	require.NoError(t, err)
+	assert.Equal(t, !pl.IsLive(), mp.EndList)
+	assert.Equal(t, pl.SegmentSize(), len(mp.Segments()))
+
+	// the media playlist doesn't share the items of the playlist
+	size := pl.ItemSize()
+	mp.Items = mp.Items[:1]
+	assert.Equal(t, size, pl.ItemSize())
+	assert.Len(t, mp.Playlist().Items, 1)
+
+	master, err := ReadFile("fixtures/master.m3u8")
+	require.NoError(t, err)
+	_, err = NewMediaPlaylist(master)
+	assert.Equal(t, ErrPlaylistInvalidType, err)
+
+	// an empty playlist is a media playlist
+	mp, err = NewMediaPlaylist(NewPlaylist())
+	require.NoError(t, err)
+	assert.Empty(t, mp.Segments())
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Attribute represents a name/value pair of an attribute list,
+// the value is kept in its original state (quoted or not)
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// AttributeList represents an attribute list keeping the attributes in their original order
+type AttributeList []Attribute
+
+// ParseAttributeList parses a text line in playlist and returns its attributes in their original order
+func ParseAttributeList(text string) AttributeList {
+	var list AttributeList
+	value := strings.Replace(text, "\n", "", -1)
+	matches := parseRegex.FindAllStringSubmatch(value, -1)
+
+	for _, match := range matches {
+		if len(match) >= 3 {
+			list = append(list, Attribute{Name: match[1], Value: match[2]})
+		}
+	}
+
+	return list
+}
+
+// Get returns the value of the attribute with the given name (the last one if repeated)
+func (l AttributeList) Get(name string) (string, bool) {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i].Name == name {
+			return l[i].Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Map returns the attributes as a map
+func (l AttributeList) Map() map[string]string {
+	m := make(map[string]string, len(l))
+	for _, a := range l {
+		m[a.Name] = a.Value
+	}
+
+	return m
+}
+
+// Names returns the names of the attributes, in order
+func (l AttributeList) Names() []string {
+	var names []string
+	for _, a := range l {
+		names = append(names, a.Name)
+	}
+
+	return names
+}
+
+// Without returns a copy of the list without the attributes with the given names
+func (l AttributeList) Without(names ...string) AttributeList {
+	var list AttributeList
+	for _, a := range l {
+		if !contains(names, a.Name) {
+			list = append(list, a)
+		}
+	}
+
+	return list
+}
+
+// Copy returns a copy of the list, so cloned items don't share it
+func (l AttributeList) Copy() AttributeList {
+	if l == nil {
+		return nil
+	}
+
+	return append(AttributeList{}, l...)
+}
+
+// Format returns the attributes formatted as NAME=VALUE, in order
+func (l AttributeList) Format() []string {
+	var slice []string
+	for _, a := range l {
+		slice = append(slice, fmt.Sprintf(FormatString, a.Name, a.Value))
+	}
+
+	return slice
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
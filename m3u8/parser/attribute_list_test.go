@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttributeList(t *testing.T) {
+	list := ParseAttributeList(`#EXT-X-KEY:URI="key,1",METHOD=AES-128,X-B=1,X-A="2",X-B=3`)
+
+	assert.Equal(t, AttributeList{
+		{Name: "URI", Value: `"key,1"`},
+		{Name: "METHOD", Value: "AES-128"},
+		{Name: "X-B", Value: "1"},
+		{Name: "X-A", Value: `"2"`},
+		{Name: "X-B", Value: "3"},
+	}, list)
+	assert.Equal(t, []string{"URI", "METHOD", "X-B", "X-A", "X-B"}, list.Names())
+
+	value, ok := list.Get("X-B")
+	assert.True(t, ok)
+	assert.Equal(t, "3", value)
+	_, ok = list.Get("IV")
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]string{"URI": `"key,1"`, "METHOD": "AES-128", "X-B": "3", "X-A": `"2"`}, list.Map())
+
+	unknown := list.Without("URI", "METHOD")
+	assert.Equal(t, []string{`X-B=1`, `X-A="2"`, `X-B=3`}, unknown.Format())
+	assert.Len(t, list, 5)
+
+	clone := unknown.Copy()
+	clone[0].Value = "0"
+	assert.Equal(t, "1", unknown[0].Value)
+	assert.Nil(t, AttributeList(nil).Copy())
+}
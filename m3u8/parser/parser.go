@@ -2,6 +2,7 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -32,24 +33,25 @@ var (
 	ErrBandwidthInvalid = errors.New("invalid bandwidth")
 )
 
+// AttributeError represents error when an attribute value can't be parsed
+type AttributeError struct {
+	Name string
+	Err  error
+}
+
+func (e *AttributeError) Error() string {
+	return fmt.Sprintf("invalid %s attribute: %v", e.Name, e.Err)
+}
+
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
+
 // ParseAttributes parses a text line in playlist and returns an attributes map
 func ParseAttributes(text string) map[string]string {
-	res := make(map[string]string)
-	value := strings.Replace(text, "\n", "", -1)
-	matches := parseRegex.FindAllStringSubmatch(value, -1)
-
-	for _, match := range matches {
-		if len(match) >= 3 {
-			key := match[1]
-			// don't remove quotes, so we preserve attribute values in their original state for unknown attributes,
-			// but we remove them while extracting in tags (knowing how to decode them back)
-			attributeValue := match[2]
-
-			res[key] = attributeValue
-		}
-	}
-
-	return res
+	// don't remove quotes, so we preserve attribute values in their original state for unknown attributes,
+	// but we remove them while extracting in tags (knowing how to decode them back)
+	return ParseAttributeList(text).Map()
 }
 
 func ParseTagName(text string) string {
@@ -74,7 +76,7 @@ func ParseFloat(attributes map[string]string, key string) (*float64, error) {
 
 	value, err := strconv.ParseFloat(stringValue, 64)
 	if err != nil {
-		return nil, err
+		return nil, &AttributeError{Name: key, Err: err}
 	}
 
 	return &value, nil
@@ -89,7 +91,7 @@ func ParseInt(attributes map[string]string, key string) (*int, error) {
 
 	int64Value, err := strconv.ParseInt(stringValue, 0, 0)
 	if err != nil {
-		return nil, err
+		return nil, &AttributeError{Name: key, Err: err}
 	}
 
 	value := int(int64Value)
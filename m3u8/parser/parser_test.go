@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -131,6 +132,9 @@ func TestParseFloat(t *testing.T) {
 		result, err := ParseFloat(attributes, tc.key)
 		if tc.hasError {
 			require.Error(t, err)
+			var attributeErr *AttributeError
+			require.True(t, errors.As(err, &attributeErr))
+			require.Equal(t, tc.key, attributeErr.Name)
 		} else {
 			require.NoError(t, err)
 		}
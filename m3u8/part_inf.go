@@ -0,0 +1,50 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// PartInf represents a #EXT-X-PART-INF tag which provides information
+// about the partial segments in a media playlist
+type PartInf struct {
+	PartTarget float64
+	attributes parser.AttributeList
+}
+
+// NewPartInf parses a text line and returns a *PartInf
+func NewPartInf(text string) (*PartInf, error) {
+	attributes := parser.ParseAttributes(text)
+
+	partTarget, err := parser.ParseFloat(attributes, PartTargetTag)
+	if err != nil {
+		return nil, err
+	}
+	if partTarget == nil {
+		return nil, ErrPartInfInvalid
+	}
+
+	unknown := parser.ParseAttributeList(text).Without(PartTargetTag)
+
+	return &PartInf{
+		PartTarget: *partTarget,
+		attributes: unknown,
+	}, nil
+}
+
+func (pi *PartInf) String() string {
+	slice := []string{fmt.Sprintf(parser.FormatString, PartTargetTag, pi.PartTarget)}
+	slice = attributesJoin(slice, pi.attributes)
+
+	return fmt.Sprintf("%s:%s", PartInfTag, strings.Join(slice, ","))
+}
+
+func (pi *PartInf) Validate() []error {
+	if pi.PartTarget <= 0 {
+		return []error{fmt.Errorf("%s attribute is not valid", PartTargetTag)}
+	}
+
+	return nil
+}
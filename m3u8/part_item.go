@@ -0,0 +1,88 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// PartItem represents a #EXT-X-PART tag, a partial segment of a
+// Low-Latency HLS media playlist
+type PartItem struct {
+	Duration    float64
+	URI         string
+	Independent *bool
+	ByteRange   *ByteRange
+	Gap         *bool
+	attributes  parser.AttributeList
+}
+
+// NewPartItem parses a text line and returns a *PartItem
+func NewPartItem(text string) (*PartItem, error) {
+	attributes := parser.ParseAttributes(text)
+
+	duration, err := parser.ParseFloat(attributes, DurationTag)
+	if err != nil {
+		return nil, err
+	}
+	if duration == nil {
+		return nil, ErrPartItemInvalid
+	}
+
+	br, err := NewByteRange(parser.SanitizeAttributeValue(attributes[ByteRangeTag]))
+	if err != nil {
+		return nil, err
+	}
+
+	unknown := parser.ParseAttributeList(text).Without(
+		DurationTag,
+		URITag,
+		IndependentTag,
+		ByteRangeTag,
+		GapTag,
+	)
+
+	return &PartItem{
+		Duration:    *duration,
+		URI:         parser.SanitizeAttributeValue(attributes[URITag]),
+		Independent: parser.ParseYesNo(attributes, IndependentTag),
+		ByteRange:   br,
+		Gap:         parser.ParseYesNo(attributes, GapTag),
+		attributes:  unknown,
+	}, nil
+}
+
+func (pi *PartItem) String() string {
+	slice := []string{
+		fmt.Sprintf(parser.FormatString, DurationTag, pi.Duration),
+		fmt.Sprintf(parser.QuotedFormatString, URITag, pi.URI),
+	}
+
+	if pi.Independent != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, IndependentTag, parser.FormatYesNo(*pi.Independent)))
+	}
+	if pi.ByteRange != nil {
+		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, ByteRangeTag, pi.ByteRange))
+	}
+	if pi.Gap != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, GapTag, parser.FormatYesNo(*pi.Gap)))
+	}
+
+	slice = attributesJoin(slice, pi.attributes)
+
+	return fmt.Sprintf("%s:%s", PartItemTag, strings.Join(slice, ","))
+}
+
+func (pi *PartItem) Validate() []error {
+	var errs []error
+
+	if pi.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", DurationTag))
+	}
+	if len(pi.URI) == 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", URITag))
+	}
+
+	return errs
+}
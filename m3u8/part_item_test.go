@@ -0,0 +1,65 @@
+package m3u8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartItem_Parse(t *testing.T) {
+	line := `#EXT-X-PART:DURATION=0.33334,URI="filePart271.0.mp4",INDEPENDENT=YES`
+
+	pi, err := NewPartItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.33334, pi.Duration)
+	assert.Equal(t, "filePart271.0.mp4", pi.URI)
+	assertNotNilEqual(t, true, pi.Independent)
+	assert.Nil(t, pi.ByteRange)
+	assert.Nil(t, pi.Gap)
+	assert.Nil(t, pi.Validate())
+
+	assertToString(t, line, pi)
+}
+
+func TestPartItem_Parse_2(t *testing.T) {
+	line := `#EXT-X-PART:DURATION=0.5,URI="segment.mp4",BYTERANGE="20000@1000",GAP=YES`
+
+	pi, err := NewPartItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, pi.Duration)
+	assert.Nil(t, pi.Independent)
+	assert.NotNil(t, pi.ByteRange)
+	assertNotNilEqual(t, 20000, pi.ByteRange.Length)
+	assertNotNilEqual(t, 1000, pi.ByteRange.Start)
+	assertNotNilEqual(t, true, pi.Gap)
+
+	assertToString(t, line, pi)
+}
+
+func TestPartItem_Invalid(t *testing.T) {
+	pi, err := NewPartItem(`#EXT-X-PART:URI="segment.mp4"`)
+	assert.Equal(t, ErrPartItemInvalid, err)
+	assert.Nil(t, pi)
+
+	pi, err = NewPartItem(`#EXT-X-PART:DURATION=x,URI="segment.mp4"`)
+	assert.Error(t, err)
+	assert.Nil(t, pi)
+
+	pi = &PartItem{}
+	assert.Len(t, pi.Validate(), 2)
+}
+
+func TestPartInf_Parse(t *testing.T) {
+	line := `#EXT-X-PART-INF:PART-TARGET=0.33334`
+
+	pi, err := NewPartInf(line)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.33334, pi.PartTarget)
+	assert.Nil(t, pi.Validate())
+
+	assertToString(t, line, pi)
+
+	pi, err = NewPartInf(`#EXT-X-PART-INF:`)
+	assert.Equal(t, ErrPartInfInvalid, err)
+	assert.Nil(t, pi)
+}
@@ -12,7 +12,7 @@ import (
 type PlaybackStart struct {
 	TimeOffset float64
 	Precise    *bool
-	attributes map[string]string
+	attributes parser.AttributeList
 }
 
 // NewPlaybackStart parses a text line and returns a *PlaybackStart
@@ -24,7 +24,7 @@ func NewPlaybackStart(text string) (*PlaybackStart, error) {
 		return nil, err
 	}
 
-	defer deleteKeys(attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		TimeOffsetTag,
 		PreciseTag,
 	)
@@ -32,7 +32,7 @@ func NewPlaybackStart(text string) (*PlaybackStart, error) {
 	return &PlaybackStart{
 		TimeOffset: timeOffset,
 		Precise:    parser.ParseYesNo(attributes, PreciseTag),
-		attributes: attributes,
+		attributes: unknown,
 	}, nil
 }
 
@@ -42,9 +42,7 @@ func (ps *PlaybackStart) String() string {
 		slice = append(slice, fmt.Sprintf(parser.FormatString, PreciseTag, parser.FormatYesNo(*ps.Precise)))
 	}
 
-	for attributeKey, attribute := range ps.attributes {
-		slice = append(slice, fmt.Sprintf(parser.FormatString, attributeKey, attribute))
-	}
+	slice = attributesJoin(slice, ps.attributes)
 
 	return fmt.Sprintf(`%s:%s`, PlaybackStartTag, strings.Join(slice, ","))
 }
@@ -20,6 +20,10 @@ type Playlist struct {
 	IndependentSegments   bool
 	Live                  bool
 	Master                *bool
+	PartInf               *PartInf
+	ServerControl         *ServerControl
+	// source holds the text the playlist was read from in lossless mode
+	source *source
 }
 
 func (pl *Playlist) String() string {
@@ -124,6 +128,39 @@ func (pl *Playlist) Segments() []*SegmentItem {
 	return s
 }
 
+// PreloadHints returns list of preload hint items in a playlist
+func (pl *Playlist) PreloadHints() []*PreloadHintItem {
+	var p []*PreloadHintItem
+	for _, i := range pl.Items {
+		if phi, ok := i.(*PreloadHintItem); ok {
+			p = append(p, phi)
+		}
+	}
+	return p
+}
+
+// RenditionReports returns list of rendition report items in a playlist
+func (pl *Playlist) RenditionReports() []*RenditionReportItem {
+	var r []*RenditionReportItem
+	for _, i := range pl.Items {
+		if rri, ok := i.(*RenditionReportItem); ok {
+			r = append(r, rri)
+		}
+	}
+	return r
+}
+
+// Warnings returns the errors of the invalid tags kept as unknown items in a playlist
+func (pl *Playlist) Warnings() []error {
+	var errs []error
+	for _, i := range pl.Items {
+		if ui, ok := i.(*UnknownItem); ok && ui.Err() != nil {
+			errs = append(errs, ui.Err())
+		}
+	}
+	return errs
+}
+
 // ItemSize returns number of items in a playlist
 func (pl *Playlist) ItemSize() int {
 	return len(pl.Items)
@@ -134,7 +171,18 @@ func (pl *Playlist) IsValid() bool {
 	return !(pl.PlaylistSize() > 0 && pl.SegmentSize() > 0)
 }
 
-// Duration returns duration of a media playlist
+// Gaps returns list of segment items marked with EXT-X-GAP in a playlist
+func (pl *Playlist) Gaps() []*SegmentItem {
+	var s []*SegmentItem
+	for _, i := range pl.Items {
+		if si, ok := i.(*SegmentItem); ok && si.Gap {
+			s = append(s, si)
+		}
+	}
+	return s
+}
+
+// Duration returns duration of a media playlist, gaps included
 func (pl *Playlist) Duration() float64 {
 	duration := 0.0
 
@@ -146,3 +194,71 @@ func (pl *Playlist) Duration() float64 {
 
 	return duration
 }
+
+// GapDuration returns duration of the segments marked with EXT-X-GAP in a media playlist
+func (pl *Playlist) GapDuration() float64 {
+	duration := 0.0
+
+	for _, segmentItem := range pl.Gaps() {
+		duration += segmentItem.Duration
+	}
+
+	return duration
+}
+
+// LiveEdge returns the end of the media timeline of a playlist in seconds,
+// including the partial segments of the segment which is not completed yet
+func (pl *Playlist) LiveEdge() float64 {
+	edge := pl.Duration()
+
+	for _, item := range pl.Items {
+		if partItem, ok := item.(*PartItem); ok {
+			edge += partItem.Duration
+		}
+	}
+
+	return edge
+}
+
+// HoldBack returns the minimum distance from the end of the playlist at which
+// playback should start, it defaults to three target durations
+func (pl *Playlist) HoldBack() float64 {
+	if pl.ServerControl != nil && pl.ServerControl.HoldBack != nil {
+		return *pl.ServerControl.HoldBack
+	}
+
+	return float64(3 * pl.Target)
+}
+
+// PartHoldBack returns the minimum distance from the live edge at which
+// low-latency playback should start, it defaults to three part targets
+// and is zero when the playlist has no partial segments information
+func (pl *Playlist) PartHoldBack() float64 {
+	if pl.ServerControl != nil && pl.ServerControl.PartHoldBack != nil {
+		return *pl.ServerControl.PartHoldBack
+	}
+	if pl.PartInf == nil {
+		return 0
+	}
+
+	return 3 * pl.PartInf.PartTarget
+}
+
+// StartPosition returns the media timeline position (in seconds from the
+// start of the playlist) where live playback should start. With lowLatency
+// set and partial segments available PART-HOLD-BACK from the live edge is
+// used, otherwise HOLD-BACK from the end of the last complete segment.
+func (pl *Playlist) StartPosition(lowLatency bool) float64 {
+	var position float64
+	if lowLatency && pl.PartInf != nil {
+		position = pl.LiveEdge() - pl.PartHoldBack()
+	} else {
+		position = pl.Duration() - pl.HoldBack()
+	}
+
+	if position < 0 {
+		return 0
+	}
+
+	return position
+}
@@ -31,7 +31,8 @@ type PlaylistItem struct {
 	HDCPLevel        *string
 	Resolution       *parser.Resolution
 	StableVariantID  *string
-	attributes       map[string]string
+	PathwayID        *string
+	attributes       parser.AttributeList
 }
 
 // NewPlaylistItem parses a text line and returns a *PlaylistItem
@@ -53,7 +54,7 @@ func NewPlaylistItem(text string, isIframe bool) *PlaylistItem {
 
 	bandwidth, _ := parser.ParseBandwidth(attributes, BandwidthTag)
 
-	defer deleteKeys(attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		ResolutionTag,
 		AverageBandwidthTag,
 		FrameRateTag,
@@ -68,6 +69,7 @@ func NewPlaylistItem(text string, isIframe bool) *PlaylistItem {
 		NameTag,
 		HDCPLevelTag,
 		StableVariantIDTag,
+		PathwayIDTag,
 	)
 
 	return &PlaylistItem{
@@ -87,8 +89,9 @@ func NewPlaylistItem(text string, isIframe bool) *PlaylistItem {
 		HDCPLevel:        parser.PointerTo(attributes, HDCPLevelTag),
 		Resolution:       resolution,
 		StableVariantID:  parser.PointerTo(attributes, StableVariantIDTag),
+		PathwayID:        parser.PointerTo(attributes, PathwayIDTag),
 		IFrame:           isIframe,
-		attributes:       attributes,
+		attributes:       unknown,
 	}
 }
 
@@ -145,6 +148,9 @@ func (pi *PlaylistItem) String() string {
 	if pi.StableVariantID != nil {
 		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, StableVariantIDTag, *pi.StableVariantID))
 	}
+	if pi.PathwayID != nil {
+		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, PathwayIDTag, *pi.PathwayID))
+	}
 
 	var uriLine string
 	itemTag := PlaylistItemTag
@@ -155,7 +161,7 @@ func (pi *PlaylistItem) String() string {
 	} else {
 		uriLine = "\n" + pi.URI
 	}
-	slice = attributesJoinMap(slice, pi.attributes)
+	slice = attributesJoin(slice, pi.attributes)
 	attributesString := strings.Join(slice, ",")
 
 	return fmt.Sprintf("%s:%s%s", itemTag, attributesString, uriLine)
@@ -249,3 +249,14 @@ NAME="1080p",HDCP-LEVEL=TYPE-0`
 func assertCodecs(t *testing.T, codecs string, p *PlaylistItem) {
 	assert.Equal(t, codecs, p.CodecsString())
 }
+
+func TestPlaylistItem_UnknownAttributesOrder(t *testing.T) {
+	line := `#EXT-X-STREAM-INF:BANDWIDTH=540,X-Z=1,X-B="2",CODECS="avc1.4d4015",X-Y=3,X-A="4"` + "\nchunklist.m3u8"
+	expected := `#EXT-X-STREAM-INF:CODECS="avc1.4d4015",BANDWIDTH=540,X-Z=1,X-B="2",X-Y=3,X-A="4"` + "\nchunklist.m3u8"
+
+	for i := 0; i < 10; i++ {
+		pi := NewPlaylistItem(line, false)
+		pi.URI = "chunklist.m3u8"
+		assert.Equal(t, expected, pi.String())
+	}
+}
@@ -29,6 +29,12 @@ func TestPlaylist_Duration(t *testing.T) {
 	}
 
 	assert.Equal(t, "40.228", fmt.Sprintf("%.3f", p.Duration()))
+	assert.Equal(t, 0.0, p.GapDuration())
+
+	p.Segments()[1].Gap = true
+	p.Segments()[3].Gap = true
+	assert.Equal(t, "40.228", fmt.Sprintf("%.3f", p.Duration()))
+	assert.Equal(t, "18.681", fmt.Sprintf("%.3f", p.GapDuration()))
 }
 
 func TestPlaylist_Master(t *testing.T) {
@@ -217,3 +223,35 @@ func TestPlaylist_Segments(t *testing.T) {
 	assert.Equal(t, 8.790, si[3].Duration)
 
 }
+
+func TestPlaylist_StartPosition(t *testing.T) {
+	p := &Playlist{
+		Target: 4,
+		Items: []Item{
+			&SegmentItem{Duration: 4, Segment: "test_01.ts"},
+			&SegmentItem{Duration: 4, Segment: "test_02.ts"},
+			&SegmentItem{Duration: 4, Segment: "test_03.ts"},
+			&SegmentItem{Duration: 4, Segment: "test_04.ts"},
+			&PartItem{Duration: 0.5, URI: "test_05.0.ts"},
+			&PartItem{Duration: 0.5, URI: "test_05.1.ts"},
+		},
+	}
+
+	assert.Equal(t, 17.0, p.LiveEdge())
+	assert.Equal(t, 12.0, p.HoldBack())
+	assert.Equal(t, 0.0, p.PartHoldBack())
+	assert.Equal(t, 4.0, p.StartPosition(false))
+	// without partial segments information low latency start falls back to HOLD-BACK
+	assert.Equal(t, 4.0, p.StartPosition(true))
+
+	p.PartInf = &PartInf{PartTarget: 0.5}
+	assert.Equal(t, 1.5, p.PartHoldBack())
+	assert.Equal(t, 15.5, p.StartPosition(true))
+
+	p.ServerControl = &ServerControl{
+		HoldBack:     pointer.ToFloat64(20),
+		PartHoldBack: pointer.ToFloat64(1),
+	}
+	assert.Equal(t, 0.0, p.StartPosition(false))
+	assert.Equal(t, 16.0, p.StartPosition(true))
+}
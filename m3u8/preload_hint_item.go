@@ -0,0 +1,91 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+const (
+	// PreloadHintTypePart is a hint of a partial segment
+	PreloadHintTypePart = "PART"
+	// PreloadHintTypeMap is a hint of a media initialization section
+	PreloadHintTypeMap = "MAP"
+)
+
+// PreloadHintItem represents a #EXT-X-PRELOAD-HINT tag which hints a resource
+// the client will need soon (LL-HLS)
+type PreloadHintItem struct {
+	Type            string
+	URI             string
+	ByteRangeStart  *int
+	ByteRangeLength *int
+	attributes      parser.AttributeList
+}
+
+// NewPreloadHintItem parses a text line and returns a *PreloadHintItem
+func NewPreloadHintItem(text string) (*PreloadHintItem, error) {
+	attributes := parser.ParseAttributes(text)
+
+	start, err := parser.ParseInt(attributes, ByteRangeStartTag)
+	if err != nil {
+		return nil, err
+	}
+	length, err := parser.ParseInt(attributes, ByteRangeLengthTag)
+	if err != nil {
+		return nil, err
+	}
+
+	unknown := parser.ParseAttributeList(text).Without(
+		TypeTag,
+		URITag,
+		ByteRangeStartTag,
+		ByteRangeLengthTag,
+	)
+
+	return &PreloadHintItem{
+		Type:            parser.SanitizeAttributeValue(attributes[TypeTag]),
+		URI:             parser.SanitizeAttributeValue(attributes[URITag]),
+		ByteRangeStart:  start,
+		ByteRangeLength: length,
+		attributes:      unknown,
+	}, nil
+}
+
+func (phi *PreloadHintItem) String() string {
+	slice := []string{
+		fmt.Sprintf(parser.FormatString, TypeTag, phi.Type),
+		fmt.Sprintf(parser.QuotedFormatString, URITag, phi.URI),
+	}
+
+	if phi.ByteRangeStart != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, ByteRangeStartTag, *phi.ByteRangeStart))
+	}
+	if phi.ByteRangeLength != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, ByteRangeLengthTag, *phi.ByteRangeLength))
+	}
+
+	slice = attributesJoin(slice, phi.attributes)
+
+	return fmt.Sprintf("%s:%s", PreloadHintItemTag, strings.Join(slice, ","))
+}
+
+func (phi *PreloadHintItem) Validate() []error {
+	var errs []error
+
+	if phi.Type != PreloadHintTypePart && phi.Type != PreloadHintTypeMap {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", TypeTag))
+	}
+	if len(phi.URI) == 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", URITag))
+	}
+	if phi.ByteRangeStart != nil && *phi.ByteRangeStart < 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", ByteRangeStartTag))
+	}
+	if phi.ByteRangeLength != nil && *phi.ByteRangeLength < 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", ByteRangeLengthTag))
+	}
+
+	return errs
+}
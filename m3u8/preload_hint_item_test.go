@@ -0,0 +1,44 @@
+package m3u8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreloadHintItem_Parse(t *testing.T) {
+	line := `#EXT-X-PRELOAD-HINT:TYPE=PART,URI="filePart273.3.mp4"`
+
+	phi, err := NewPreloadHintItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, PreloadHintTypePart, phi.Type)
+	assert.Equal(t, "filePart273.3.mp4", phi.URI)
+	assert.Nil(t, phi.ByteRangeStart)
+	assert.Nil(t, phi.ByteRangeLength)
+	assert.Nil(t, phi.Validate())
+
+	assertToString(t, line, phi)
+}
+
+func TestPreloadHintItem_Parse_2(t *testing.T) {
+	line := `#EXT-X-PRELOAD-HINT:TYPE=MAP,URI="init.mp4",BYTERANGE-START=0,BYTERANGE-LENGTH=720`
+
+	phi, err := NewPreloadHintItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, PreloadHintTypeMap, phi.Type)
+	assertNotNilEqual(t, 0, phi.ByteRangeStart)
+	assertNotNilEqual(t, 720, phi.ByteRangeLength)
+	assert.Nil(t, phi.Validate())
+
+	assertToString(t, line, phi)
+}
+
+func TestPreloadHintItem_Invalid(t *testing.T) {
+	phi, err := NewPreloadHintItem(`#EXT-X-PRELOAD-HINT:TYPE=PART,URI="a.mp4",BYTERANGE-START=x`)
+	assert.Error(t, err)
+	assert.Nil(t, phi)
+
+	phi, err = NewPreloadHintItem(`#EXT-X-PRELOAD-HINT:TYPE=SEGMENT`)
+	assert.Nil(t, err)
+	assert.Len(t, phi.Validate(), 2)
+}
@@ -0,0 +1,76 @@
+package m3u8
+
+import "time"
+
+// ProgramDateTimes returns the date and time of every segment of a media playlist (in the order of Segments):
+// segments without #EXT-X-PROGRAM-DATE-TIME get it interpolated from the nearest one using segment durations.
+// Interpolation doesn't cross #EXT-X-DISCONTINUITY, the date is nil for segments of a discontinuity without any.
+func (pl *Playlist) ProgramDateTimes() []*time.Time {
+	segments := pl.ResolvedSegments()
+	times := make([]*time.Time, len(segments))
+
+	// forward from the dates set
+	var previous *time.Time
+	for i, rs := range segments {
+		if rs.Discontinuity {
+			previous = nil
+		}
+		switch {
+		case rs.ProgramDateTime != nil:
+			t := *rs.ProgramDateTime
+			times[i] = &t
+		case previous != nil:
+			t := previous.Add(seconds(segments[i-1].Segment.Duration))
+			times[i] = &t
+		}
+		previous = times[i]
+	}
+
+	// backward for the segments preceding the first date of their discontinuity
+	var next *time.Time
+	for i := len(segments) - 1; i >= 0; i-- {
+		if times[i] == nil && next != nil {
+			t := next.Add(-seconds(segments[i].Segment.Duration))
+			times[i] = &t
+		}
+		next = times[i]
+		if segments[i].Discontinuity {
+			next = nil
+		}
+	}
+
+	return times
+}
+
+// TimeOf returns the date and time of the segment at an index of Segments, see ProgramDateTimes
+func (pl *Playlist) TimeOf(segmentIndex int) (time.Time, error) {
+	times := pl.ProgramDateTimes()
+	if segmentIndex < 0 || segmentIndex >= len(times) {
+		return time.Time{}, ErrSegmentNotFound
+	}
+	if times[segmentIndex] == nil {
+		return time.Time{}, ErrProgramDateTimeMissing
+	}
+
+	return *times[segmentIndex], nil
+}
+
+// SegmentAt returns the index in Segments of the segment playing at a date and time, see ProgramDateTimes
+func (pl *Playlist) SegmentAt(t time.Time) (int, error) {
+	segments := pl.Segments()
+	for i, start := range pl.ProgramDateTimes() {
+		if start == nil || t.Before(*start) {
+			continue
+		}
+		if t.Before(start.Add(seconds(segments[i].Duration))) {
+			return i, nil
+		}
+	}
+
+	return -1, ErrSegmentNotFound
+}
+
+// seconds converts a duration in seconds to a time.Duration
+func seconds(d float64) time.Duration {
+	return time.Duration(d * float64(time.Second))
+}
@@ -0,0 +1,86 @@
+package m3u8
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylist_ProgramDateTimes(t *testing.T) {
+	pl, err := ReadString(strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXTINF:10,",
+		"s0.ts",
+		"#EXTINF:6.5,",
+		"s1.ts",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:16.5Z",
+		"#EXTINF:10,",
+		"s2.ts",
+		"#EXTINF:10,",
+		"s3.ts",
+		"#EXT-X-DISCONTINUITY",
+		"#EXTINF:5,",
+		"a0.ts",
+		"#EXTINF:5,",
+		"a1.ts",
+		"#EXT-X-DISCONTINUITY",
+		"#EXTINF:10,",
+		"s4.ts",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T01:00:10Z",
+		"#EXTINF:10,",
+		"s5.ts",
+	}, "\n"))
+	require.NoError(t, err)
+
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := pl.ProgramDateTimes()
+	require.Len(t, times, 8)
+	expected := []*time.Time{
+		timePtr(base),
+		timePtr(base.Add(10 * time.Second)),
+		timePtr(base.Add(16500 * time.Millisecond)),
+		timePtr(base.Add(26500 * time.Millisecond)),
+		nil,
+		nil,
+		timePtr(base.Add(time.Hour)),
+		timePtr(base.Add(time.Hour + 10*time.Second)),
+	}
+	for i := range expected {
+		if expected[i] == nil {
+			assert.Nil(t, times[i], i)
+			continue
+		}
+		require.NotNil(t, times[i], i)
+		assert.True(t, expected[i].Equal(*times[i]), "%d: %v", i, times[i])
+	}
+
+	ts, err := pl.TimeOf(3)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(base.Add(26500*time.Millisecond)))
+	_, err = pl.TimeOf(4)
+	assert.Equal(t, ErrProgramDateTimeMissing, err)
+	_, err = pl.TimeOf(8)
+	assert.Equal(t, ErrSegmentNotFound, err)
+
+	i, err := pl.SegmentAt(base.Add(12 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+	i, err = pl.SegmentAt(base.Add(16500 * time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, 2, i)
+	i, err = pl.SegmentAt(base.Add(time.Hour + 19*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 7, i)
+	_, err = pl.SegmentAt(base.Add(-time.Second))
+	assert.Equal(t, ErrSegmentNotFound, err)
+	_, err = pl.SegmentAt(base.Add(40 * time.Second))
+	assert.Equal(t, ErrSegmentNotFound, err)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -1,7 +1,7 @@
 package m3u8
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +14,72 @@ type state struct {
 	open        bool
 	currentItem Item
 	master      bool
+	// parts holds #EXT-X-PART items not yet claimed by a segment,
+	// partsStart is the index in Playlist.Items of the first of them
+	parts      []*PartItem
+	partsStart int
+	// gap is set by #EXT-X-GAP preceding #EXTINF
+	gap bool
+	// line is the number of the line being parsed
+	line int
+	// strict is set to fail on invalid known tags instead of keeping them as UnknownItem
+	strict bool
+	// tags holds the custom tags of the reader
+	tags *TagRegistry
+}
+
+// ReadOptions represents options of reading a playlist
+type ReadOptions struct {
+	// Strict makes reading fail with a *ParseError when a known tag is invalid,
+	// otherwise (lenient mode) the tag is kept as an UnknownItem recording the error,
+	// see Playlist.Warnings
+	Strict bool
+	// Tags holds decoders of custom tags, consulted before the ones registered with RegisterTag
+	Tags *TagRegistry
+	// Lossless keeps the source text of the playlist, so Write re-emits the unchanged items
+	// and playlist tags verbatim (order, formatting and blank lines included),
+	// only the items modified since reading are written anew. It applies to Decode only.
+	Lossless bool
+}
+
+// lineError returns a *ParseError of the line being parsed
+func (st *state) lineError(line string, err error) error {
+	err = parseError(line, err)
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.Line == 0 {
+		pe.Line = st.line
+	}
+
+	return err
+}
+
+// appendPart adds a part to the playlist items; the part stays there
+// only if no #EXTINF follows it (i.e. it belongs to the live edge)
+func (st *state) appendPart(pl *Playlist, item *PartItem) {
+	if len(st.parts) == 0 {
+		st.partsStart = len(pl.Items)
+	}
+	st.parts = append(st.parts, item)
+	pl.Items = append(pl.Items, item)
+}
+
+// attachParts moves pending parts from the playlist items to the segment they precede
+func (st *state) attachParts(pl *Playlist, si *SegmentItem) {
+	if len(st.parts) == 0 {
+		return
+	}
+
+	items := pl.Items[:st.partsStart]
+	for _, item := range pl.Items[st.partsStart:] {
+		if _, ok := item.(*PartItem); ok {
+			continue
+		}
+		items = append(items, item)
+	}
+	pl.Items = items
+
+	si.Parts = st.parts
+	st.parts = nil
 }
 
 // ReadString parses a text string and returns a playlist
@@ -23,68 +89,58 @@ func ReadString(text string) (*Playlist, error) {
 
 // ReadFile reads text from a file and returns a playlist
 func ReadFile(path string) (*Playlist, error) {
-	f, err := os.ReadFile(path)
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	return Read(bytes.NewReader(f))
+	defer f.Close()
+
+	return Read(f)
 }
 
 // Read reads text from an io.Reader and returns a playlist
 func Read(reader io.Reader) (*Playlist, error) {
-	var buf bytes.Buffer
-	_, err := buf.ReadFrom(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	pl := NewPlaylist()
-	st := &state{}
-	header := true
-	eof := false
-
-	for !eof {
-		line, err := buf.ReadString('\n')
-		if err != nil {
-			if err != io.EOF {
-				return nil, err
-			}
+	return NewDecoder(reader).Decode()
+}
 
-			eof = true
-		}
+// ReadWithOptions reads text from an io.Reader and returns a playlist
+func ReadWithOptions(reader io.Reader, opts ReadOptions) (*Playlist, error) {
+	return NewDecoderWithOptions(reader, opts).Decode()
+}
 
-		value := strings.TrimSpace(line)
-		if len(value) == 0 {
-			continue
-		}
-		if header && value != HeaderTag {
-			return nil, ErrPlaylistInvalid
-		}
+// TypedPlaylist represents a *MasterPlaylist or a *MediaPlaylist
+type TypedPlaylist interface {
+	// Playlist returns the playlist as a *Playlist
+	Playlist() *Playlist
+	String() string
+}
 
-		if value == HeaderTag {
-			if header == false {
-				return nil, ErrPlaylistInvalid
-			}
-			header = false
-			continue
-		}
+// Decode reads text from an io.Reader and returns a *MasterPlaylist or a *MediaPlaylist,
+// depending on the type of the playlist read
+func Decode(reader io.Reader) (TypedPlaylist, error) {
+	return DecodeWithOptions(reader, ReadOptions{})
+}
 
-		if err := parseLine(value, pl, st); err != nil {
+// DecodeWithOptions reads text from an io.Reader and returns a *MasterPlaylist or a *MediaPlaylist,
+// depending on the type of the playlist read
+func DecodeWithOptions(reader io.Reader, opts ReadOptions) (TypedPlaylist, error) {
+	pl, err := ReadWithOptions(reader, opts)
+	if err != nil {
+		return nil, err
+	}
+	if pl.IsMaster() {
+		mp, err := NewMasterPlaylist(pl)
+		if err != nil {
 			return nil, err
 		}
+		return mp, nil
 	}
 
-	if pl.Version == nil {
-		version := 1
-		pl.Version = &version
-	}
-
-	if !pl.IsValid() {
-		return nil, ErrPlaylistInvalidType
-
+	mp, err := NewMediaPlaylist(pl)
+	if err != nil {
+		return nil, err
 	}
-
-	return pl, nil
+	return mp, nil
 }
 
 // parseLine parses all tags and attributes (implemented by this lib)
@@ -99,7 +155,10 @@ func parseLine(line string, pl *Playlist, st *state) error {
 
 		err := tagValue.ReadLine(line, pl, st)
 		if err != nil {
-			pl.Items = append(pl.Items, NewUnknownItem(line, nil))
+			if st.strict {
+				return st.lineError(line, err)
+			}
+			pl.Items = append(pl.Items, NewUnknownItem(line, st.lineError(line, err)))
 		}
 		break
 	}
@@ -107,8 +166,20 @@ func parseLine(line string, pl *Playlist, st *state) error {
 	if lineIsParsed {
 		return nil
 	}
+
+	lineIsParsed, err := readCustomTag(line, pl, st)
+	if err != nil {
+		if st.strict {
+			return st.lineError(line, err)
+		}
+		pl.Items = append(pl.Items, NewUnknownItem(line, st.lineError(line, err)))
+	}
+	if lineIsParsed {
+		return nil
+	}
+
 	if st.currentItem != nil && st.open {
-		return parseNextLine(line, pl, st)
+		return st.lineError(line, parseNextLine(line, pl, st))
 	}
 
 	pl.Items = append(pl.Items, NewUnknownItem(line, nil))
@@ -182,5 +253,55 @@ func parseError(line string, err error) error {
 	if err == nil {
 		return nil
 	}
-	return fmt.Errorf("error: %v when parsing playlist error for line: %s", err, line)
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return err
+	}
+
+	return &ParseError{
+		Column: errorColumn(line, err),
+		Tag:    parser.ParseTagName(line),
+		Text:   line,
+		Err:    err,
+	}
+}
+
+// errorColumn returns the position of the invalid attribute in a line if it's known,
+// otherwise the position of the tag value
+func errorColumn(line string, err error) int {
+	var attributeErr *parser.AttributeError
+	if errors.As(err, &attributeErr) {
+		if i := attributeIndex(line, attributeErr.Name); i >= 0 {
+			return i + 1
+		}
+	}
+
+	if i := strings.Index(line, ":"); i >= 0 && parser.ParseTagName(line) != "" {
+		return i + 2
+	}
+
+	return 1
+}
+
+// attributeIndex returns the index of an attribute in a line, -1 if it's missing:
+// attributes start after the tag or after a comma which is not within a quoted string
+func attributeIndex(line, name string) int {
+	start := strings.Index(line, ":")
+	if start < 0 {
+		return -1
+	}
+
+	quoted := false
+	for i := start; i < len(line); i++ {
+		switch {
+		case line[i] == '"':
+			quoted = !quoted
+		case (i == start || line[i] == ',') && !quoted:
+			if strings.HasPrefix(line[i+1:], name+"=") {
+				return i + 1
+			}
+		}
+	}
+
+	return -1
 }
@@ -289,6 +289,94 @@ func TestReader_DateRange(t *testing.T) {
 	assert.IsType(t, segment, p.Items[3])
 }
 
+func TestReader_LowLatency(t *testing.T) {
+	p, err := ReadFile("fixtures/llhls.m3u8")
+	assert.Nil(t, err)
+	assert.True(t, p.IsValid())
+	assert.False(t, p.IsMaster())
+	assert.True(t, p.IsLive())
+
+	assert.NotNil(t, p.PartInf)
+	assert.Equal(t, 0.33334, p.PartInf.PartTarget)
+
+	assert.NotNil(t, p.ServerControl)
+	assertNotNilEqual(t, true, p.ServerControl.CanBlockReload)
+	assertNotNilEqual(t, 1.0, p.ServerControl.PartHoldBack)
+	assertNotNilEqual(t, 12.0, p.ServerControl.CanSkipUntil)
+	assert.Nil(t, p.ServerControl.HoldBack)
+	assert.IsType(t, &TimeItem{}, p.Items[0])
+
+	segments := p.Segments()
+	assert.Len(t, segments, 7)
+	assert.Empty(t, segments[4].Parts)
+
+	si := segments[5]
+	assert.Equal(t, "fileSequence271.mp4", si.Segment)
+	assert.Len(t, si.Parts, 12)
+	assert.Equal(t, "filePart271.0.mp4", si.Parts[0].URI)
+	assertNotNilEqual(t, true, si.Parts[0].Independent)
+	assert.Nil(t, si.Parts[1].Independent)
+
+	si = segments[6]
+	assert.Len(t, si.Parts, 12)
+	assert.Equal(t, "filePart272.l.mp4", si.Parts[11].URI)
+
+	// parts of the segment which is not completed yet stay in the playlist items
+	var parts []*PartItem
+	for _, item := range p.Items {
+		if pi, ok := item.(*PartItem); ok {
+			parts = append(parts, pi)
+		}
+	}
+	assert.Len(t, parts, 3)
+	assert.Equal(t, "filePart273.0.mp4", parts[0].URI)
+	assert.Equal(t, "filePart273.2.mp4", parts[2].URI)
+
+	hints := p.PreloadHints()
+	assert.Len(t, hints, 1)
+	assert.Equal(t, PreloadHintTypePart, hints[0].Type)
+	assert.Equal(t, "filePart273.3.mp4", hints[0].URI)
+
+	reports := p.RenditionReports()
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "../1M/waitForMSN.php", reports[0].URI)
+	assertNotNilEqual(t, 273, reports[0].LastMSN)
+	assertNotNilEqual(t, 2, reports[0].LastPart)
+	assertNotNilEqual(t, 1, reports[1].LastPart)
+}
+
+func TestReader_Gap(t *testing.T) {
+	s := strings.Join([]string{
+		HeaderTag,
+		TargetDurationTag + ":4",
+		"#EXTINF:4,",
+		"segment1.ts",
+		GapItemTag,
+		"#EXTINF:4,",
+		"segment2.ts",
+		"#EXTINF:4,",
+		GapItemTag,
+		"segment3.ts",
+		"#EXTINF:4,",
+		"segment4.ts",
+	}, "\n")
+
+	pl, err := ReadString(s)
+	require.NoError(t, err)
+	require.Len(t, pl.Items, 4)
+
+	segments := pl.Segments()
+	assert.False(t, segments[0].Gap)
+	assert.True(t, segments[1].Gap)
+	assert.True(t, segments[2].Gap)
+	assert.False(t, segments[3].Gap)
+	assert.Equal(t, []*SegmentItem{segments[1], segments[2]}, pl.Gaps())
+
+	decoded, err := ReadString(pl.String())
+	require.NoError(t, err)
+	assert.Equal(t, pl.Items, decoded.Items)
+}
+
 func TestReader_Invalid(t *testing.T) {
 	_, err := ReadFile("path/to/file")
 	assert.NotNil(t, err)
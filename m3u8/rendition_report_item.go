@@ -0,0 +1,75 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// RenditionReportItem represents a #EXT-X-RENDITION-REPORT tag which carries
+// information about an associated rendition of the same variant stream (LL-HLS)
+type RenditionReportItem struct {
+	URI        string
+	LastMSN    *int
+	LastPart   *int
+	attributes parser.AttributeList
+}
+
+// NewRenditionReportItem parses a text line and returns a *RenditionReportItem
+func NewRenditionReportItem(text string) (*RenditionReportItem, error) {
+	attributes := parser.ParseAttributes(text)
+
+	lastMSN, err := parser.ParseInt(attributes, LastMSNTag)
+	if err != nil {
+		return nil, err
+	}
+	lastPart, err := parser.ParseInt(attributes, LastPartTag)
+	if err != nil {
+		return nil, err
+	}
+
+	unknown := parser.ParseAttributeList(text).Without(
+		URITag,
+		LastMSNTag,
+		LastPartTag,
+	)
+
+	return &RenditionReportItem{
+		URI:        parser.SanitizeAttributeValue(attributes[URITag]),
+		LastMSN:    lastMSN,
+		LastPart:   lastPart,
+		attributes: unknown,
+	}, nil
+}
+
+func (rri *RenditionReportItem) String() string {
+	slice := []string{fmt.Sprintf(parser.QuotedFormatString, URITag, rri.URI)}
+
+	if rri.LastMSN != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, LastMSNTag, *rri.LastMSN))
+	}
+	if rri.LastPart != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, LastPartTag, *rri.LastPart))
+	}
+
+	slice = attributesJoin(slice, rri.attributes)
+
+	return fmt.Sprintf("%s:%s", RenditionReportItemTag, strings.Join(slice, ","))
+}
+
+func (rri *RenditionReportItem) Validate() []error {
+	var errs []error
+
+	if len(rri.URI) == 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", URITag))
+	}
+	if rri.LastMSN != nil && *rri.LastMSN < 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", LastMSNTag))
+	}
+	if rri.LastPart != nil && (*rri.LastPart < 0 || rri.LastMSN == nil) {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", LastPartTag))
+	}
+
+	return errs
+}
@@ -0,0 +1,41 @@
+package m3u8
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenditionReportItem_Parse(t *testing.T) {
+	line := `#EXT-X-RENDITION-REPORT:URI="../1M/waitForMSN.php",LAST-MSN=273,LAST-PART=2`
+
+	rri, err := NewRenditionReportItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, "../1M/waitForMSN.php", rri.URI)
+	assertNotNilEqual(t, 273, rri.LastMSN)
+	assertNotNilEqual(t, 2, rri.LastPart)
+	assert.Nil(t, rri.Validate())
+
+	assertToString(t, line, rri)
+}
+
+func TestRenditionReportItem_Parse_2(t *testing.T) {
+	line := `#EXT-X-RENDITION-REPORT:URI="../1M/waitForMSN.php"`
+
+	rri, err := NewRenditionReportItem(line)
+	assert.Nil(t, err)
+	assert.Nil(t, rri.LastMSN)
+	assert.Nil(t, rri.LastPart)
+
+	assertToString(t, line, rri)
+}
+
+func TestRenditionReportItem_Invalid(t *testing.T) {
+	rri, err := NewRenditionReportItem(`#EXT-X-RENDITION-REPORT:URI="a.m3u8",LAST-MSN=x`)
+	assert.Error(t, err)
+	assert.Nil(t, rri)
+
+	rri = &RenditionReportItem{LastPart: pointer.ToInt(1)}
+	assert.Len(t, rri.Validate(), 2)
+}
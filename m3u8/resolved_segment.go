@@ -0,0 +1,154 @@
+package m3u8
+
+import (
+	"time"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// ResolvedSegment represents a segment of a media playlist
+// along with the state set by the tags preceding it
+type ResolvedSegment struct {
+	Segment *SegmentItem
+	// MediaSequence is the media sequence number of the segment
+	MediaSequence int
+	// DiscontinuitySequence is the discontinuity sequence number of the segment
+	DiscontinuitySequence int
+	// Discontinuity is set when the segment follows #EXT-X-DISCONTINUITY
+	Discontinuity bool
+	// Offset is the position in seconds of the segment from the start of the playlist
+	Offset float64
+	// Keys holds the #EXT-X-KEY tags in effect (one per KEYFORMAT), none when the segment is not encrypted
+	Keys []*KeyItem
+	// Map is the #EXT-X-MAP in effect
+	Map *MapItem
+	// ByteRange is the sub-range of the segment resource with its start resolved
+	ByteRange *ByteRange
+	// ProgramDateTime is the date and time of the first sample of the segment
+	// set by #EXT-X-PROGRAM-DATE-TIME
+	ProgramDateTime *time.Time
+	// DateRanges holds the #EXT-X-DATERANGE tags preceding the segment
+	DateRanges []*DateRangeItem
+}
+
+// Key returns the first #EXT-X-KEY in effect, nil when the segment is not encrypted
+func (rs *ResolvedSegment) Key() *KeyItem {
+	if len(rs.Keys) == 0 {
+		return nil
+	}
+
+	return rs.Keys[0]
+}
+
+// ResolvedSegments returns the segments of a media playlist along with the state set by the tags preceding them
+func (pl *Playlist) ResolvedSegments() []*ResolvedSegment {
+	if pl.IsMaster() {
+		return nil
+	}
+
+	var (
+		segments      []*ResolvedSegment
+		keys          []*KeyItem
+		mapItem       *MapItem
+		pdt           *time.Time
+		dateRanges    []*DateRangeItem
+		discontinuity bool
+		offset        float64
+		previous      *SegmentItem
+		previousEnd   int
+	)
+	sequence := pl.Sequence
+	discontinuitySequence := 0
+	if pl.DiscontinuitySequence != nil {
+		discontinuitySequence = *pl.DiscontinuitySequence
+	}
+
+	for _, item := range pl.Items {
+		switch it := item.(type) {
+		case *KeyItem:
+			keys = applyKey(keys, it)
+		case *MapItem:
+			mapItem = it
+		case *TimeItem:
+			t := it.Time
+			pdt = &t
+		case *DateRangeItem:
+			dateRanges = append(dateRanges, it)
+		case *DiscontinuityItem:
+			if !discontinuity {
+				discontinuitySequence++
+			}
+			discontinuity = true
+		case *SegmentItem:
+			rs := &ResolvedSegment{
+				Segment:               it,
+				MediaSequence:         sequence,
+				DiscontinuitySequence: discontinuitySequence,
+				Discontinuity:         discontinuity,
+				Offset:                offset,
+				Keys:                  keys,
+				Map:                   mapItem,
+				ProgramDateTime:       pdt,
+				DateRanges:            dateRanges,
+			}
+			if it.ProgramDateTime != nil {
+				t := it.ProgramDateTime.Time
+				rs.ProgramDateTime = &t
+			}
+			if it.ByteRange != nil && it.ByteRange.Length != nil {
+				start := 0
+				switch {
+				case it.ByteRange.Start != nil:
+					start = *it.ByteRange.Start
+				case previous != nil && previous.ByteRange != nil && previous.Segment == it.Segment:
+					start = previousEnd
+				}
+				length := *it.ByteRange.Length
+				rs.ByteRange = &ByteRange{Length: &length, Start: &start}
+				previousEnd = start + length
+			}
+			segments = append(segments, rs)
+
+			sequence++
+			offset += it.Duration
+			previous = it
+			pdt = nil
+			dateRanges = nil
+			discontinuity = false
+		}
+	}
+
+	return segments
+}
+
+// ResolvedSegments returns the segments of the media playlist along with the state set by the tags preceding them
+func (mp *MediaPlaylist) ResolvedSegments() []*ResolvedSegment {
+	return mp.Playlist().ResolvedSegments()
+}
+
+// applyKey returns the keys in effect after an #EXT-X-KEY tag:
+// it replaces the key of the same KEYFORMAT, METHOD=NONE removes them all
+func applyKey(keys []*KeyItem, key *KeyItem) []*KeyItem {
+	if key.Encryptable == nil || key.Encryptable.Method == parser.NoneValue {
+		return nil
+	}
+
+	format := keyFormat(key)
+	result := make([]*KeyItem, 0, len(keys)+1)
+	for _, k := range keys {
+		if keyFormat(k) != format {
+			result = append(result, k)
+		}
+	}
+
+	return append(result, key)
+}
+
+// keyFormat returns the KEYFORMAT of a key, "identity" when not set
+func keyFormat(key *KeyItem) string {
+	if key.Encryptable.KeyFormat == nil {
+		return "identity"
+	}
+
+	return *key.Encryptable.KeyFormat
+}
@@ -0,0 +1,111 @@
+package m3u8
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylist_ResolvedSegments(t *testing.T) {
+	pl, err := ReadString(strings.Join([]string{
+		"#EXTM3U",
+		"#EXT-X-TARGETDURATION:10",
+		"#EXT-X-MEDIA-SEQUENCE:100",
+		"#EXT-X-DISCONTINUITY-SEQUENCE:3",
+		`#EXT-X-KEY:METHOD=SAMPLE-AES,URI="skd://key",KEYFORMAT="com.apple.streamingkeydelivery"`,
+		`#EXT-X-KEY:METHOD=SAMPLE-AES,URI="data:text/plain;base64,AAAA",KEYFORMAT="urn:uuid:edef8ba9-79d6-4ace-a3c8-27dcd51d21ed"`,
+		`#EXT-X-MAP:URI="init.mp4"`,
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:00Z",
+		`#EXT-X-DATERANGE:ID="ad",START-DATE="2020-01-01T00:00:00Z"`,
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:1000@0",
+		"main.mp4",
+		"#EXTINF:10,",
+		"#EXT-X-BYTERANGE:2000",
+		"main.mp4",
+		"#EXT-X-DISCONTINUITY",
+		"#EXT-X-KEY:METHOD=NONE",
+		`#EXT-X-MAP:URI="ad-init.mp4"`,
+		"#EXTINF:5,",
+		"#EXT-X-PROGRAM-DATE-TIME:2020-01-01T01:00:00Z",
+		"ad.mp4",
+		`#EXT-X-KEY:METHOD=SAMPLE-AES,URI="skd://key2",KEYFORMAT="com.apple.streamingkeydelivery"`,
+		"#EXTINF:5,",
+		"#EXT-X-BYTERANGE:500",
+		"other.mp4",
+	}, "\n"))
+	require.NoError(t, err)
+
+	segments := pl.ResolvedSegments()
+	require.Len(t, segments, 4)
+
+	first := segments[0]
+	assert.Equal(t, "main.mp4", first.Segment.Segment)
+	assert.Equal(t, 100, first.MediaSequence)
+	assert.Equal(t, 3, first.DiscontinuitySequence)
+	assert.False(t, first.Discontinuity)
+	assert.Equal(t, 0.0, first.Offset)
+	require.Len(t, first.Keys, 2)
+	assert.Equal(t, "skd://key", *first.Key().Encryptable.URI)
+	assert.Equal(t, "init.mp4", first.Map.URI)
+	assert.Equal(t, "1000@0", first.ByteRange.String())
+	require.NotNil(t, first.ProgramDateTime)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), first.ProgramDateTime.UTC())
+	require.Len(t, first.DateRanges, 1)
+	assert.Equal(t, "ad", first.DateRanges[0].ID)
+
+	second := segments[1]
+	assert.Equal(t, 101, second.MediaSequence)
+	assert.Equal(t, 10.0, second.Offset)
+	assert.Equal(t, "2000@1000", second.ByteRange.String())
+	assert.Nil(t, second.ProgramDateTime)
+	assert.Empty(t, second.DateRanges)
+	assert.Len(t, second.Keys, 2)
+
+	third := segments[2]
+	assert.Equal(t, 102, third.MediaSequence)
+	assert.Equal(t, 4, third.DiscontinuitySequence)
+	assert.True(t, third.Discontinuity)
+	assert.Nil(t, third.Key())
+	assert.Equal(t, "ad-init.mp4", third.Map.URI)
+	assert.Nil(t, third.ByteRange)
+	require.NotNil(t, third.ProgramDateTime)
+	assert.Equal(t, time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC), third.ProgramDateTime.UTC())
+
+	fourth := segments[3]
+	assert.Equal(t, 4, fourth.DiscontinuitySequence)
+	assert.False(t, fourth.Discontinuity)
+	require.Len(t, fourth.Keys, 1)
+	assert.Equal(t, "skd://key2", *fourth.Key().Encryptable.URI)
+	// a sub-range of another resource starts at 0
+	assert.Equal(t, "500@0", fourth.ByteRange.String())
+	assert.Equal(t, 25.0, fourth.Offset)
+}
+
+func TestPlaylist_ResolvedSegments_Fixture(t *testing.T) {
+	pl, err := ReadFile("fixtures/fer_with_ads.m3u8")
+	require.NoError(t, err)
+
+	segments := pl.ResolvedSegments()
+	require.Len(t, segments, pl.SegmentSize())
+	assert.Equal(t, 773, segments[0].MediaSequence)
+	assert.NotNil(t, segments[0].Key())
+
+	last := segments[len(segments)-1]
+	assert.Equal(t, 773+len(segments)-1, last.MediaSequence)
+	for _, rs := range segments {
+		require.NotNil(t, rs.Map)
+		if strings.Contains(rs.Segment.Segment, "/atp/") {
+			assert.Nil(t, rs.Key(), rs.Segment.Segment)
+		} else {
+			assert.NotNil(t, rs.Key(), rs.Segment.Segment)
+		}
+	}
+
+	master, err := ReadFile("fixtures/master.m3u8")
+	require.NoError(t, err)
+	assert.Nil(t, master.ResolvedSegments())
+}
@@ -32,6 +32,7 @@ func TestRetentionProperDecoding(t *testing.T) {
 		{filePath: "fixtures/vod_non_drm_compact.m3u8"},
 		{filePath: "fixtures/vod_pre_roll.m3u8"},
 		{filePath: "fixtures/vod_short_form.m3u8"},
+		{filePath: "fixtures/llhls.m3u8"},
 	}
 
 	for _, tc := range testCases {
@@ -56,6 +57,8 @@ func TestRetentionProperDecoding(t *testing.T) {
 		require.Equal(t, p.Master, decodedPlaylist.Master)
 		require.Equal(t, p.Target, decodedPlaylist.Target)
 		require.Equal(t, p.Version, decodedPlaylist.Version)
+		require.Equal(t, p.PartInf, decodedPlaylist.PartInf)
+		require.Equal(t, p.ServerControl, decodedPlaylist.ServerControl)
 		require.Equal(t, len(p.Items), len(decodedPlaylist.Items))
 
 		for i := 0; i < len(p.Items); i++ {
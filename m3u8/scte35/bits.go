@@ -0,0 +1,110 @@
+package scte35
+
+// bitReader reads big-endian bit fields, reading past the end sets err
+type bitReader struct {
+	data []byte
+	pos  int // in bits
+	err  error
+}
+
+func newBitReader(data []byte) *bitReader {
+	return &bitReader{data: data}
+}
+
+// bits reads a field of n bits (n <= 64)
+func (r *bitReader) bits(n int) uint64 {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos+n > len(r.data)*8 {
+		r.err = ErrSectionTruncated
+		r.pos = len(r.data) * 8
+		return 0
+	}
+
+	var v uint64
+	for i := 0; i < n; i++ {
+		b := r.data[r.pos/8] >> (7 - uint(r.pos%8)) & 1
+		v = v<<1 | uint64(b)
+		r.pos++
+	}
+
+	return v
+}
+
+func (r *bitReader) flag() bool {
+	return r.bits(1) == 1
+}
+
+func (r *bitReader) uint8() uint8 {
+	return uint8(r.bits(8))
+}
+
+func (r *bitReader) skip(n int) {
+	r.bits(n)
+}
+
+// bytes reads n bytes, the reader must be byte aligned
+func (r *bitReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	start := r.pos / 8
+	if n < 0 || start+n > len(r.data) {
+		r.err = ErrSectionTruncated
+		r.pos = len(r.data) * 8
+		return nil
+	}
+	r.pos += n * 8
+
+	return append([]byte(nil), r.data[start:start+n]...)
+}
+
+// offset returns the position in bytes
+func (r *bitReader) offset() int {
+	return r.pos / 8
+}
+
+// bitWriter writes big-endian bit fields, a value not fitting its field sets err
+type bitWriter struct {
+	data []byte
+	pos  int // in bits
+	err  error
+}
+
+// bits writes a field of n bits (n <= 64)
+func (w *bitWriter) bits(n int, v uint64) {
+	if n < 64 && v>>uint(n) != 0 && w.err == nil {
+		w.err = ErrSectionInvalid
+	}
+	for i := n - 1; i >= 0; i-- {
+		if w.pos%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		w.data[len(w.data)-1] |= byte(v>>uint(i)&1) << (7 - uint(w.pos%8))
+		w.pos++
+	}
+}
+
+func (w *bitWriter) flag(b bool) {
+	if b {
+		w.bits(1, 1)
+		return
+	}
+	w.bits(1, 0)
+}
+
+func (w *bitWriter) uint8(v uint8) {
+	w.bits(8, uint64(v))
+}
+
+// reserved writes n reserved bits, set to 1 as required by SCTE-35
+func (w *bitWriter) reserved(n int) {
+	w.bits(n, 1<<uint(n)-1)
+}
+
+// bytes writes bytes, the writer must be byte aligned
+func (w *bitWriter) bytes(b []byte) {
+	w.data = append(w.data, b...)
+	w.pos += len(b) * 8
+}
@@ -0,0 +1,219 @@
+package scte35
+
+// Splice command types
+const (
+	SpliceNullType           = 0x00
+	SpliceScheduleType       = 0x04
+	SpliceInsertType         = 0x05
+	TimeSignalType           = 0x06
+	BandwidthReservationType = 0x07
+	PrivateCommandType       = 0xFF
+)
+
+// SpliceCommand represents the splice command of a splice_info_section
+type SpliceCommand interface {
+	CommandType() uint8
+}
+
+// SpliceNull represents a splice_null command
+type SpliceNull struct{}
+
+// CommandType returns the splice_command_type
+func (c *SpliceNull) CommandType() uint8 {
+	return SpliceNullType
+}
+
+// SpliceInsert represents a splice_insert command
+type SpliceInsert struct {
+	EventID           uint32
+	EventCancel       bool
+	OutOfNetwork      bool
+	ProgramSplice     bool
+	SpliceImmediate   bool
+	EventIDCompliance bool
+	// SpliceTime is the pts_time of a program splice, nil when splice immediate or the time is not specified
+	SpliceTime *uint64
+	// Components are set when the splice is not a program splice
+	Components    []SpliceInsertComponent
+	BreakDuration *BreakDuration
+	// UniqueProgramID, AvailNum and AvailsExpected are not set when the event is canceled
+	UniqueProgramID uint16
+	AvailNum        uint8
+	AvailsExpected  uint8
+}
+
+// CommandType returns the splice_command_type
+func (c *SpliceInsert) CommandType() uint8 {
+	return SpliceInsertType
+}
+
+// SpliceInsertComponent represents a component of a splice_insert
+type SpliceInsertComponent struct {
+	ComponentTag uint8
+	// SpliceTime is the pts_time of the component, nil when splice immediate or the time is not specified
+	SpliceTime *uint64
+}
+
+// BreakDuration represents the break_duration of a splice_insert
+type BreakDuration struct {
+	AutoReturn bool
+	// Duration in 90 kHz ticks
+	Duration uint64
+}
+
+// TimeSignal represents a time_signal command
+type TimeSignal struct {
+	// SpliceTime is the pts_time, nil when the time is not specified
+	SpliceTime *uint64
+}
+
+// CommandType returns the splice_command_type
+func (c *TimeSignal) CommandType() uint8 {
+	return TimeSignalType
+}
+
+// RawCommand represents a splice command not decoded by this package (e.g. splice_schedule, private_command)
+type RawCommand struct {
+	Type uint8
+	Data []byte
+}
+
+// CommandType returns the splice_command_type
+func (c *RawCommand) CommandType() uint8 {
+	return c.Type
+}
+
+// decodeSpliceCommand decodes the splice command of a type,
+// the commands not decoded by this package take the rest of the reader (the splice_command_length bytes)
+func decodeSpliceCommand(commandType uint8, r *bitReader) (SpliceCommand, error) {
+	var command SpliceCommand
+	switch commandType {
+	case SpliceNullType:
+		command = &SpliceNull{}
+	case SpliceInsertType:
+		command = decodeSpliceInsert(r)
+	case TimeSignalType:
+		command = &TimeSignal{SpliceTime: decodeSpliceTime(r)}
+	default:
+		command = &RawCommand{Type: commandType, Data: r.bytes(len(r.data) - r.offset())}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return command, nil
+}
+
+func decodeSpliceInsert(r *bitReader) *SpliceInsert {
+	c := &SpliceInsert{EventID: uint32(r.bits(32))}
+	c.EventCancel = r.flag()
+	r.skip(7)
+	if c.EventCancel {
+		return c
+	}
+
+	c.OutOfNetwork = r.flag()
+	c.ProgramSplice = r.flag()
+	durationFlag := r.flag()
+	c.SpliceImmediate = r.flag()
+	c.EventIDCompliance = r.flag()
+	r.skip(3)
+
+	if c.ProgramSplice && !c.SpliceImmediate {
+		c.SpliceTime = decodeSpliceTime(r)
+	}
+	if !c.ProgramSplice {
+		count := int(r.uint8())
+		for i := 0; i < count && r.err == nil; i++ {
+			component := SpliceInsertComponent{ComponentTag: r.uint8()}
+			if !c.SpliceImmediate {
+				component.SpliceTime = decodeSpliceTime(r)
+			}
+			c.Components = append(c.Components, component)
+		}
+	}
+	if durationFlag {
+		c.BreakDuration = &BreakDuration{AutoReturn: r.flag()}
+		r.skip(6)
+		c.BreakDuration.Duration = r.bits(33)
+	}
+	c.UniqueProgramID = uint16(r.bits(16))
+	c.AvailNum = r.uint8()
+	c.AvailsExpected = r.uint8()
+
+	return c
+}
+
+// decodeSpliceTime decodes a splice_time, returning nil when the time is not specified
+func decodeSpliceTime(r *bitReader) *uint64 {
+	if !r.flag() {
+		r.skip(7)
+		return nil
+	}
+	r.skip(6)
+	pts := r.bits(33)
+
+	return &pts
+}
+
+// encodeSpliceCommand encodes the fields of a splice command
+func encodeSpliceCommand(command SpliceCommand, w *bitWriter) {
+	switch c := command.(type) {
+	case *SpliceNull:
+	case *SpliceInsert:
+		encodeSpliceInsert(c, w)
+	case *TimeSignal:
+		encodeSpliceTime(c.SpliceTime, w)
+	case *RawCommand:
+		w.bytes(c.Data)
+	}
+}
+
+func encodeSpliceInsert(c *SpliceInsert, w *bitWriter) {
+	w.bits(32, uint64(c.EventID))
+	w.flag(c.EventCancel)
+	w.reserved(7)
+	if c.EventCancel {
+		return
+	}
+
+	w.flag(c.OutOfNetwork)
+	w.flag(c.ProgramSplice)
+	w.flag(c.BreakDuration != nil)
+	w.flag(c.SpliceImmediate)
+	w.flag(c.EventIDCompliance)
+	w.reserved(3)
+
+	if c.ProgramSplice && !c.SpliceImmediate {
+		encodeSpliceTime(c.SpliceTime, w)
+	}
+	if !c.ProgramSplice {
+		w.bits(8, uint64(len(c.Components)))
+		for _, component := range c.Components {
+			w.uint8(component.ComponentTag)
+			if !c.SpliceImmediate {
+				encodeSpliceTime(component.SpliceTime, w)
+			}
+		}
+	}
+	if c.BreakDuration != nil {
+		w.flag(c.BreakDuration.AutoReturn)
+		w.reserved(6)
+		w.bits(33, c.BreakDuration.Duration)
+	}
+	w.bits(16, uint64(c.UniqueProgramID))
+	w.uint8(c.AvailNum)
+	w.uint8(c.AvailsExpected)
+}
+
+// encodeSpliceTime encodes a splice_time, a nil pts_time is not specified
+func encodeSpliceTime(pts *uint64, w *bitWriter) {
+	if pts == nil {
+		w.flag(false)
+		w.reserved(7)
+		return
+	}
+	w.flag(true)
+	w.reserved(6)
+	w.bits(33, *pts)
+}
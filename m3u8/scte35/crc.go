@@ -0,0 +1,28 @@
+package scte35
+
+// crcTable is the table of the CRC-32/MPEG-2 checksum (polynomial 0x04C11DB7, not reflected)
+var crcTable = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		crc := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// crc32 returns the CRC-32/MPEG-2 checksum of data, as used by splice_info_section
+func crc32(data []byte) uint32 {
+	crc := uint32(0xFFFFFFFF)
+	for _, b := range data {
+		crc = crc<<8 ^ crcTable[byte(crc>>24)^b]
+	}
+
+	return crc
+}
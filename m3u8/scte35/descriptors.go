@@ -0,0 +1,267 @@
+package scte35
+
+import "fmt"
+
+// Splice descriptor tags
+const (
+	AvailDescriptorTag        = 0x00
+	DTMFDescriptorTag         = 0x01
+	SegmentationDescriptorTag = 0x02
+	TimeDescriptorTag         = 0x03
+	AudioDescriptorTag        = 0x04
+)
+
+// Descriptor represents a splice_descriptor of a splice_info_section
+type Descriptor interface {
+	DescriptorTag() uint8
+}
+
+// SpliceDescriptor represents a splice descriptor not decoded by this package
+type SpliceDescriptor struct {
+	Tag        uint8
+	Identifier uint32
+	// Data holds the bytes following the identifier
+	Data []byte
+}
+
+// DescriptorTag returns the splice_descriptor_tag
+func (d *SpliceDescriptor) DescriptorTag() uint8 {
+	return d.Tag
+}
+
+// SegmentationDescriptor represents a segmentation_descriptor
+type SegmentationDescriptor struct {
+	Identifier        uint32
+	EventID           uint32
+	EventCancel       bool
+	EventIDCompliance bool
+	// the fields below are not set when the event is canceled
+	ProgramSegmentation   bool
+	DeliveryNotRestricted bool
+	// WebDeliveryAllowed, NoRegionalBlackout, ArchiveAllowed and DeviceRestrictions
+	// are set when the delivery is restricted
+	WebDeliveryAllowed bool
+	NoRegionalBlackout bool
+	ArchiveAllowed     bool
+	DeviceRestrictions uint8
+	// Components are set when the segmentation is not a program segmentation
+	Components []SegmentationComponent
+	// Duration in 90 kHz ticks
+	Duration         *uint64
+	UPIDType         UPIDType
+	UPID             []byte
+	TypeID           SegmentationType
+	SegmentNum       uint8
+	SegmentsExpected uint8
+	// SubSegmentNum and SubSegmentsExpected are set for the types of segmentation with sub-segments
+	SubSegmentNum       *uint8
+	SubSegmentsExpected *uint8
+}
+
+// DescriptorTag returns the splice_descriptor_tag
+func (d *SegmentationDescriptor) DescriptorTag() uint8 {
+	return SegmentationDescriptorTag
+}
+
+// DurationSeconds returns the segmentation_duration in seconds, nil when not set
+func (d *SegmentationDescriptor) DurationSeconds() *float64 {
+	if d.Duration == nil {
+		return nil
+	}
+
+	seconds := Seconds(*d.Duration)
+	return &seconds
+}
+
+// SegmentationUPID returns the segmentation_upid of the descriptor
+func (d *SegmentationDescriptor) SegmentationUPID() *UPID {
+	return &UPID{Type: d.UPIDType, Data: d.UPID}
+}
+
+// SegmentationComponent represents a component of a segmentation_descriptor
+type SegmentationComponent struct {
+	ComponentTag uint8
+	PTSOffset    uint64
+}
+
+// decodeDescriptors decodes the descriptor loop of a splice_info_section
+func decodeDescriptors(data []byte) ([]Descriptor, error) {
+	var descriptors []Descriptor
+	r := newBitReader(data)
+	for r.offset() < len(data) {
+		tag := r.uint8()
+		body := r.bytes(int(r.uint8()))
+		if r.err != nil {
+			return nil, r.err
+		}
+		if len(body) < 4 {
+			return nil, fmt.Errorf("%w: splice_descriptor 0x%02X is too short", ErrSectionInvalid, tag)
+		}
+
+		br := newBitReader(body)
+		identifier := uint32(br.bits(32))
+		if tag != SegmentationDescriptorTag || identifier != CUEIIdentifier {
+			descriptors = append(descriptors, &SpliceDescriptor{Tag: tag, Identifier: identifier, Data: body[4:]})
+			continue
+		}
+
+		d := decodeSegmentationDescriptor(br)
+		if br.err != nil {
+			return nil, br.err
+		}
+		d.Identifier = identifier
+		descriptors = append(descriptors, d)
+	}
+
+	return descriptors, nil
+}
+
+func decodeSegmentationDescriptor(r *bitReader) *SegmentationDescriptor {
+	d := &SegmentationDescriptor{EventID: uint32(r.bits(32))}
+	d.EventCancel = r.flag()
+	d.EventIDCompliance = r.flag()
+	r.skip(6)
+	if d.EventCancel {
+		return d
+	}
+
+	d.ProgramSegmentation = r.flag()
+	durationFlag := r.flag()
+	d.DeliveryNotRestricted = r.flag()
+	if d.DeliveryNotRestricted {
+		r.skip(5)
+	} else {
+		d.WebDeliveryAllowed = r.flag()
+		d.NoRegionalBlackout = r.flag()
+		d.ArchiveAllowed = r.flag()
+		d.DeviceRestrictions = uint8(r.bits(2))
+	}
+
+	if !d.ProgramSegmentation {
+		count := int(r.uint8())
+		for i := 0; i < count && r.err == nil; i++ {
+			component := SegmentationComponent{ComponentTag: r.uint8()}
+			r.skip(7)
+			component.PTSOffset = r.bits(33)
+			d.Components = append(d.Components, component)
+		}
+	}
+	if durationFlag {
+		duration := r.bits(40)
+		d.Duration = &duration
+	}
+
+	d.UPIDType = UPIDType(r.uint8())
+	d.UPID = r.bytes(int(r.uint8()))
+	d.TypeID = SegmentationType(r.uint8())
+	d.SegmentNum = r.uint8()
+	d.SegmentsExpected = r.uint8()
+
+	// sub_segment_num and sub_segments_expected were added later, so they may be missing
+	if d.TypeID.HasSubSegments() && r.offset()+2 <= len(r.data) {
+		num, expected := r.uint8(), r.uint8()
+		d.SubSegmentNum = &num
+		d.SubSegmentsExpected = &expected
+	}
+
+	return d
+}
+
+// NewSegmentationDescriptor returns a program segmentation_descriptor with delivery not restricted,
+// the segment number and expected segments are set to 1 for the types of segmentation of the advertisements
+func NewSegmentationDescriptor(eventID uint32, typeID SegmentationType, upidType UPIDType, upid []byte) *SegmentationDescriptor {
+	d := &SegmentationDescriptor{
+		Identifier:            CUEIIdentifier,
+		EventID:               eventID,
+		EventIDCompliance:     true,
+		ProgramSegmentation:   true,
+		DeliveryNotRestricted: true,
+		UPIDType:              upidType,
+		UPID:                  upid,
+		TypeID:                typeID,
+	}
+	if typeID >= ProviderAdStart && typeID <= DistributorPlacementOpportunityEnd {
+		d.SegmentNum = 1
+		d.SegmentsExpected = 1
+	}
+
+	return d
+}
+
+// encodeDescriptors encodes the descriptor loop of a splice_info_section
+func encodeDescriptors(descriptors []Descriptor, w *bitWriter) error {
+	for _, descriptor := range descriptors {
+		body := &bitWriter{}
+		switch d := descriptor.(type) {
+		case *SegmentationDescriptor:
+			encodeSegmentationDescriptor(d, body)
+		case *SpliceDescriptor:
+			body.bits(32, uint64(d.Identifier))
+			body.bytes(d.Data)
+		default:
+			return fmt.Errorf("%w: unsupported splice_descriptor %T", ErrSectionInvalid, descriptor)
+		}
+		if body.err != nil {
+			return fmt.Errorf("%w: splice_descriptor 0x%02X field exceeds its size", ErrSectionInvalid, descriptor.DescriptorTag())
+		}
+		if len(body.data) > 0xFF {
+			return fmt.Errorf("%w: splice_descriptor 0x%02X is too long", ErrSectionInvalid, descriptor.DescriptorTag())
+		}
+
+		w.uint8(descriptor.DescriptorTag())
+		w.bits(8, uint64(len(body.data)))
+		w.bytes(body.data)
+	}
+
+	return nil
+}
+
+func encodeSegmentationDescriptor(d *SegmentationDescriptor, w *bitWriter) {
+	identifier := d.Identifier
+	if identifier == 0 {
+		identifier = CUEIIdentifier
+	}
+	w.bits(32, uint64(identifier))
+	w.bits(32, uint64(d.EventID))
+	w.flag(d.EventCancel)
+	w.flag(d.EventIDCompliance)
+	w.reserved(6)
+	if d.EventCancel {
+		return
+	}
+
+	w.flag(d.ProgramSegmentation)
+	w.flag(d.Duration != nil)
+	w.flag(d.DeliveryNotRestricted)
+	if d.DeliveryNotRestricted {
+		w.reserved(5)
+	} else {
+		w.flag(d.WebDeliveryAllowed)
+		w.flag(d.NoRegionalBlackout)
+		w.flag(d.ArchiveAllowed)
+		w.bits(2, uint64(d.DeviceRestrictions))
+	}
+
+	if !d.ProgramSegmentation {
+		w.bits(8, uint64(len(d.Components)))
+		for _, component := range d.Components {
+			w.uint8(component.ComponentTag)
+			w.reserved(7)
+			w.bits(33, component.PTSOffset)
+		}
+	}
+	if d.Duration != nil {
+		w.bits(40, *d.Duration)
+	}
+
+	w.uint8(uint8(d.UPIDType))
+	w.bits(8, uint64(len(d.UPID)))
+	w.bytes(d.UPID)
+	w.uint8(uint8(d.TypeID))
+	w.uint8(d.SegmentNum)
+	w.uint8(d.SegmentsExpected)
+	if d.SubSegmentNum != nil && d.SubSegmentsExpected != nil {
+		w.uint8(*d.SubSegmentNum)
+		w.uint8(*d.SubSegmentsExpected)
+	}
+}
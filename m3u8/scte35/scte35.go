@@ -0,0 +1,280 @@
+// Package scte35 provides the SCTE-35 splice_info_section model and a binary decoder,
+// used for the cues carried by #EXT-X-SCTE35 and #EXT-X-DATERANGE tags
+package scte35
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// TableID is the table_id of a splice_info_section
+	TableID = 0xFC
+
+	// TicksPerSecond is the frequency of the PTS and durations (90 kHz clock)
+	TicksPerSecond = 90000
+
+	// SAPTypeUnspecified is the sap_type of a section not specifying the type of stream access point
+	SAPTypeUnspecified = 3
+
+	// CUEIIdentifier is the identifier ("CUEI") of the splice descriptors defined by SCTE-35
+	CUEIIdentifier = 0x43554549
+
+	// ptsMask keeps the 33 bits of a PTS
+	ptsMask = 1<<33 - 1
+)
+
+var (
+	// ErrSectionInvalid represents error when a splice_info_section doesn't comply with the specification
+	ErrSectionInvalid = errors.New("invalid splice_info_section")
+
+	// ErrSectionTruncated represents error when a splice_info_section is shorter than its fields require
+	ErrSectionTruncated = errors.New("truncated splice_info_section")
+
+	// ErrCRC32Mismatch represents error when the CRC_32 of a splice_info_section doesn't match its content
+	ErrCRC32Mismatch = errors.New("splice_info_section CRC_32 mismatch")
+
+	// ErrSectionEncrypted represents error when the splice command of a splice_info_section is encrypted
+	ErrSectionEncrypted = errors.New("encrypted splice_info_section is not supported")
+
+	// ErrUPIDInvalid represents error when a UPID can't be parsed
+	ErrUPIDInvalid = errors.New("invalid UPID")
+
+	// ErrSegmentationTypeInvalid represents error when a segmentation type can't be parsed
+	ErrSegmentationTypeInvalid = errors.New("invalid segmentation type")
+)
+
+// SpliceInfoSection represents a SCTE-35 splice_info_section
+type SpliceInfoSection struct {
+	SAPType             uint8
+	ProtocolVersion     uint8
+	EncryptedPacket     bool
+	EncryptionAlgorithm uint8
+	// PTSAdjustment is added to the PTS of the section (see AdjustPTS)
+	PTSAdjustment uint64
+	CWIndex       uint8
+	Tier          uint16
+	SpliceCommand SpliceCommand
+	Descriptors   []Descriptor
+	CRC32         uint32
+}
+
+// NewSpliceInfoSection returns a section of a splice command, not encrypted and without tier
+func NewSpliceInfoSection(command SpliceCommand, descriptors ...Descriptor) *SpliceInfoSection {
+	return &SpliceInfoSection{
+		SAPType:       SAPTypeUnspecified,
+		Tier:          0xFFF,
+		SpliceCommand: command,
+		Descriptors:   descriptors,
+	}
+}
+
+// SegmentationDescriptors returns the segmentation_descriptor of the section
+func (s *SpliceInfoSection) SegmentationDescriptors() []*SegmentationDescriptor {
+	var result []*SegmentationDescriptor
+	for _, d := range s.Descriptors {
+		if sd, ok := d.(*SegmentationDescriptor); ok {
+			result = append(result, sd)
+		}
+	}
+
+	return result
+}
+
+// AdjustPTS applies the pts_adjustment of the section to a PTS of its command
+func (s *SpliceInfoSection) AdjustPTS(pts uint64) uint64 {
+	return (pts + s.PTSAdjustment) & ptsMask
+}
+
+// PTS returns the splice time of a time_signal or a program splice_insert with pts_adjustment applied,
+// nil when the command has no splice time (e.g. splice immediate)
+func (s *SpliceInfoSection) PTS() *uint64 {
+	var pts *uint64
+	switch cmd := s.SpliceCommand.(type) {
+	case *TimeSignal:
+		pts = cmd.SpliceTime
+	case *SpliceInsert:
+		pts = cmd.SpliceTime
+	}
+	if pts == nil {
+		return nil
+	}
+
+	adjusted := s.AdjustPTS(*pts)
+	return &adjusted
+}
+
+// Seconds converts 90 kHz ticks to seconds
+func Seconds(ticks uint64) float64 {
+	return float64(ticks) / TicksPerSecond
+}
+
+// Ticks converts seconds to 90 kHz ticks
+func Ticks(seconds float64) uint64 {
+	return uint64(math.Round(seconds * TicksPerSecond))
+}
+
+// Decode decodes a binary splice_info_section, verifying its CRC_32
+func Decode(data []byte) (*SpliceInfoSection, error) {
+	r := newBitReader(data)
+	if r.uint8() != TableID {
+		return nil, fmt.Errorf("%w: table_id is not 0x%X", ErrSectionInvalid, TableID)
+	}
+	r.skip(2) // section_syntax_indicator, private_indicator
+	s := &SpliceInfoSection{SAPType: uint8(r.bits(2))}
+	length := int(r.bits(12))
+	if r.err != nil {
+		return nil, r.err
+	}
+	end := r.offset() + length
+	if end > len(data) {
+		return nil, ErrSectionTruncated
+	}
+	if length < 4 {
+		return nil, fmt.Errorf("%w: section_length %d", ErrSectionInvalid, length)
+	}
+	data = data[:end]
+
+	s.CRC32 = uint32(data[end-4])<<24 | uint32(data[end-3])<<16 | uint32(data[end-2])<<8 | uint32(data[end-1])
+	if crc32(data[:end-4]) != s.CRC32 {
+		return nil, ErrCRC32Mismatch
+	}
+
+	// the CRC_32 is not part of the fields
+	r = newBitReader(data[:end-4])
+	r.skip(24)
+	s.ProtocolVersion = r.uint8()
+	s.EncryptedPacket = r.flag()
+	s.EncryptionAlgorithm = uint8(r.bits(6))
+	s.PTSAdjustment = r.bits(33)
+	s.CWIndex = r.uint8()
+	s.Tier = uint16(r.bits(12))
+	commandLength := int(r.bits(12))
+	commandType := r.uint8()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if s.EncryptedPacket {
+		return nil, ErrSectionEncrypted
+	}
+
+	var command SpliceCommand
+	var err error
+	switch {
+	case commandLength != 0xFFF:
+		command, err = decodeSpliceCommand(commandType, newBitReader(r.bytes(commandLength)))
+	case commandType == SpliceNullType || commandType == SpliceInsertType || commandType == TimeSignalType:
+		// 0xFFF is the legacy value of an unknown splice_command_length
+		command, err = decodeSpliceCommand(commandType, r)
+	default:
+		return nil, fmt.Errorf("%w: unknown splice_command_length", ErrSectionInvalid)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.SpliceCommand = command
+
+	descriptors, err := decodeDescriptors(r.bytes(int(r.bits(16))))
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Descriptors = descriptors
+
+	return s, nil
+}
+
+// DecodeBase64 decodes a base64 splice_info_section (e.g. #EXT-X-SCTE35 CUE)
+func DecodeBase64(s string) (*SpliceInfoSection, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSectionInvalid, err)
+	}
+
+	return Decode(data)
+}
+
+// DecodeHex decodes a hexadecimal splice_info_section with an optional 0x prefix (e.g. #EXT-X-DATERANGE SCTE35-OUT)
+func DecodeHex(s string) (*SpliceInfoSection, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSectionInvalid, err)
+	}
+
+	return Decode(data)
+}
+
+// Encode encodes a splice_info_section, computing its lengths and CRC_32 (the CRC32 field is ignored)
+func Encode(s *SpliceInfoSection) ([]byte, error) {
+	if s.EncryptedPacket {
+		return nil, ErrSectionEncrypted
+	}
+	if s.SpliceCommand == nil {
+		return nil, fmt.Errorf("%w: splice command is missing", ErrSectionInvalid)
+	}
+
+	command := &bitWriter{}
+	encodeSpliceCommand(s.SpliceCommand, command)
+	descriptors := &bitWriter{}
+	if err := encodeDescriptors(s.Descriptors, descriptors); err != nil {
+		return nil, err
+	}
+
+	w := &bitWriter{}
+	w.uint8(TableID)
+	w.flag(false) // section_syntax_indicator
+	w.flag(false) // private_indicator
+	w.bits(2, uint64(s.SAPType))
+	// the fields following section_length, with CRC_32
+	w.bits(12, uint64(11+len(command.data)+2+len(descriptors.data)+4))
+	w.uint8(s.ProtocolVersion)
+	w.flag(false) // encrypted_packet
+	w.bits(6, uint64(s.EncryptionAlgorithm))
+	w.bits(33, s.PTSAdjustment)
+	w.uint8(s.CWIndex)
+	w.bits(12, uint64(s.Tier))
+	w.bits(12, uint64(len(command.data)))
+	w.uint8(s.SpliceCommand.CommandType())
+	w.bytes(command.data)
+	w.bits(16, uint64(len(descriptors.data)))
+	w.bytes(descriptors.data)
+	if command.err != nil || w.err != nil {
+		return nil, fmt.Errorf("%w: field exceeds its size", ErrSectionInvalid)
+	}
+
+	w.bits(32, uint64(crc32(w.data)))
+	return w.data, nil
+}
+
+// Base64 encodes the section in base64 (e.g. for #EXT-X-SCTE35 CUE)
+func (s *SpliceInfoSection) Base64() (string, error) {
+	data, err := Encode(s)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Hex encodes the section in hexadecimal with the 0x prefix (e.g. for #EXT-X-DATERANGE SCTE35-OUT)
+func (s *SpliceInfoSection) Hex() (string, error) {
+	data, err := Encode(s)
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + strings.ToUpper(hex.EncodeToString(data)), nil
+}
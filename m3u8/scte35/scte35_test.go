@@ -0,0 +1,224 @@
+package scte35
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// spliceInsertCue is the splice_insert sample of the SCTE-35 specification
+	spliceInsertCue = "/DAvAAAAAAAA///wFAVIAACPf+/+c2nALv4AUsz1AAAAAAAKAAhDVUVJAAABNWLbowo="
+	timeSignalCue   = "/DCRAAAAAAAAAP/wBQb+Er0CvAB7AnlDVUVJAAAABX//AACk84oMZU5CQ1V7ImFzc2V0SWQiOiJwZWFjb2NrXzcwMzA2OSIsImN1ZURhdGEiOnsiY3VlVHlwZSI6InN0YW5kYXJkX2JyZWFrIiwia2V5IjoicGIiLCJ2YWx1ZSI6InN0YW5kYXJkIn19MAEBxkYw3w=="
+	timeSignalHex   = "0xFC309100000000000000FFF00506FEB64A439D007B0279435545490000017D7FC30000A4D2890C654E4243557B2261737365744964223A22706561636F636B5F363030313131222C2263756544617461223A7B2263756554797065223A227374616E646172645F627265616B222C226B6579223A227062222C2276616C7565223A227374616E64617264227D7D30000022F97D8F"
+)
+
+func TestDecode_SpliceInsert(t *testing.T) {
+	s, err := DecodeBase64(spliceInsertCue)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(3), s.SAPType)
+	assert.Equal(t, uint8(0xFF), s.CWIndex)
+	assert.Equal(t, uint16(0xFFF), s.Tier)
+
+	cmd, ok := s.SpliceCommand.(*SpliceInsert)
+	require.True(t, ok)
+	assert.Equal(t, &SpliceInsert{
+		EventID:           0x4800008F,
+		OutOfNetwork:      true,
+		ProgramSplice:     true,
+		EventIDCompliance: true,
+		SpliceTime:        pointer.ToUint64(0x07369C02E),
+		BreakDuration:     &BreakDuration{AutoReturn: true, Duration: 0x00052CCF5},
+	}, cmd)
+	assert.Equal(t, pointer.ToUint64(0x07369C02E), s.PTS())
+
+	require.Len(t, s.Descriptors, 1)
+	assert.Equal(t, &SpliceDescriptor{
+		Tag:        AvailDescriptorTag,
+		Identifier: CUEIIdentifier,
+		Data:       []byte{0x00, 0x00, 0x01, 0x35},
+	}, s.Descriptors[0])
+	assert.Empty(t, s.SegmentationDescriptors())
+}
+
+func TestDecode_TimeSignal(t *testing.T) {
+	s, err := DecodeBase64(timeSignalCue)
+	require.NoError(t, err)
+	assert.Equal(t, &TimeSignal{SpliceTime: pointer.ToUint64(0x12BD02BC)}, s.SpliceCommand)
+
+	descriptors := s.SegmentationDescriptors()
+	require.Len(t, descriptors, 1)
+	d := descriptors[0]
+	assert.Equal(t, uint32(5), d.EventID)
+	assert.True(t, d.ProgramSegmentation)
+	assert.True(t, d.DeliveryNotRestricted)
+	assert.Equal(t, pointer.ToUint64(10810250), d.Duration)
+	assert.InDelta(t, 120.114, *d.DurationSeconds(), 0.001)
+	assert.Equal(t, UPIDMPU, d.UPIDType)
+	assert.Contains(t, string(d.UPID), `NBCU{"assetId":"peacock_703069"`)
+	assert.Equal(t, ProviderAdStart, d.TypeID)
+	assert.Equal(t, uint8(1), d.SegmentNum)
+	assert.Equal(t, uint8(1), d.SegmentsExpected)
+	assert.Nil(t, d.SubSegmentNum)
+
+	h, err := DecodeHex(timeSignalHex)
+	require.NoError(t, err)
+	d = h.SegmentationDescriptors()[0]
+	assert.Equal(t, uint32(381), d.EventID)
+	assert.False(t, d.DeliveryNotRestricted)
+	assert.False(t, d.WebDeliveryAllowed)
+	assert.False(t, d.NoRegionalBlackout)
+	assert.Equal(t, uint8(3), d.DeviceRestrictions)
+	assert.InDelta(t, 120.02, *d.DurationSeconds(), 0.001)
+}
+
+func TestDecode_PTSAdjustment(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(timeSignalCue)
+	require.NoError(t, err)
+
+	// pts_adjustment = 2^33 - 1 wraps around
+	data[4] |= 0x01
+	for i := 5; i < 9; i++ {
+		data[i] = 0xFF
+	}
+	setCRC(data)
+
+	s, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1<<33-1), s.PTSAdjustment)
+	assert.Equal(t, pointer.ToUint64(0x12BD02BC-1), s.PTS())
+	assert.Equal(t, uint64(0x12BD02BC), *s.SpliceCommand.(*TimeSignal).SpliceTime)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(timeSignalCue)
+	require.NoError(t, err)
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[20] ^= 0xFF
+	_, err = Decode(corrupted)
+	assert.Equal(t, ErrCRC32Mismatch, err)
+
+	_, err = Decode(data[:len(data)-1])
+	assert.Equal(t, ErrSectionTruncated, err)
+
+	_, err = Decode(append([]byte{0xFD}, data[1:]...))
+	assert.True(t, errors.Is(err, ErrSectionInvalid))
+
+	encrypted := append([]byte(nil), data...)
+	encrypted[4] |= 0x80
+	setCRC(encrypted)
+	_, err = Decode(encrypted)
+	assert.Equal(t, ErrSectionEncrypted, err)
+
+	// segmentation_upid_length beyond the descriptor
+	truncated := append([]byte(nil), data...)
+	truncated[39] = 0xFF
+	setCRC(truncated)
+	_, err = Decode(truncated)
+	assert.Equal(t, ErrSectionTruncated, err)
+
+	_, err = DecodeBase64("not base64")
+	assert.True(t, errors.Is(err, ErrSectionInvalid))
+
+	_, err = DecodeHex("0xZZ")
+	assert.True(t, errors.Is(err, ErrSectionInvalid))
+}
+
+func setCRC(data []byte) {
+	crc := crc32(data[:len(data)-4])
+	data[len(data)-4] = byte(crc >> 24)
+	data[len(data)-3] = byte(crc >> 16)
+	data[len(data)-2] = byte(crc >> 8)
+	data[len(data)-1] = byte(crc)
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, cue := range []string{spliceInsertCue, timeSignalCue} {
+		s, err := DecodeBase64(cue)
+		require.NoError(t, err)
+		encoded, err := s.Base64()
+		require.NoError(t, err)
+		assert.Equal(t, cue, encoded)
+	}
+
+	s, err := DecodeHex(timeSignalHex)
+	require.NoError(t, err)
+	encoded, err := s.Hex()
+	require.NoError(t, err)
+	assert.Equal(t, timeSignalHex, encoded)
+}
+
+func TestEncode_TimeSignal(t *testing.T) {
+	upid := []byte("peacock_703069")
+	for typeID := ProviderAdStart; typeID <= DistributorPlacementOpportunityEnd; typeID++ {
+		d := NewSegmentationDescriptor(7, typeID, 0x0E, upid)
+		d.Duration = pointer.ToUint64(Ticks(30.03))
+		if typeID.HasSubSegments() {
+			d.SubSegmentNum = pointer.ToUint8(1)
+			d.SubSegmentsExpected = pointer.ToUint8(2)
+		}
+		s := NewSpliceInfoSection(&TimeSignal{SpliceTime: pointer.ToUint64(0x12BD02BC)}, d)
+
+		cue, err := s.Base64()
+		require.NoError(t, err)
+		decoded, err := DecodeBase64(cue)
+		require.NoError(t, err)
+		s.CRC32 = decoded.CRC32
+		assert.Equal(t, s, decoded)
+		assert.InDelta(t, 30.03, *decoded.SegmentationDescriptors()[0].DurationSeconds(), 0.0001)
+		assert.Equal(t, uint8(1), decoded.SegmentationDescriptors()[0].SegmentNum)
+	}
+}
+
+func TestEncode_SpliceInsert(t *testing.T) {
+	s := NewSpliceInfoSection(&SpliceInsert{
+		EventID:      0x4800008F,
+		OutOfNetwork: true,
+		Components: []SpliceInsertComponent{
+			{ComponentTag: 1, SpliceTime: pointer.ToUint64(90000)},
+			{ComponentTag: 2},
+		},
+		BreakDuration:   &BreakDuration{AutoReturn: true, Duration: Ticks(60)},
+		UniqueProgramID: 1,
+		AvailNum:        1,
+		AvailsExpected:  2,
+	})
+	s.PTSAdjustment = 1234
+
+	out, err := s.Hex()
+	require.NoError(t, err)
+	assert.Regexp(t, "^0xFC30", out)
+	decoded, err := DecodeHex(out)
+	require.NoError(t, err)
+	s.CRC32 = decoded.CRC32
+	assert.Equal(t, s, decoded)
+
+	canceled := NewSpliceInfoSection(&SpliceInsert{EventID: 1, EventCancel: true}, &SegmentationDescriptor{EventID: 1, EventCancel: true})
+	data, err := Encode(canceled)
+	require.NoError(t, err)
+	decoded, err = Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, canceled.SpliceCommand, decoded.SpliceCommand)
+	assert.Equal(t, &SegmentationDescriptor{Identifier: CUEIIdentifier, EventID: 1, EventCancel: true}, decoded.Descriptors[0])
+}
+
+func TestEncode_Errors(t *testing.T) {
+	_, err := Encode(NewSpliceInfoSection(&TimeSignal{SpliceTime: pointer.ToUint64(1 << 33)}))
+	assert.True(t, errors.Is(err, ErrSectionInvalid))
+
+	_, err = Encode(NewSpliceInfoSection(&TimeSignal{}, NewSegmentationDescriptor(1, ProviderAdStart, 0x0F, make([]byte, 256))))
+	assert.True(t, errors.Is(err, ErrSectionInvalid))
+
+	_, err = Encode(NewSpliceInfoSection(nil))
+	assert.True(t, errors.Is(err, ErrSectionInvalid))
+
+	s := NewSpliceInfoSection(&SpliceNull{})
+	s.EncryptedPacket = true
+	_, err = Encode(s)
+	assert.Equal(t, ErrSectionEncrypted, err)
+}
@@ -0,0 +1,160 @@
+package scte35
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SegmentationType represents the segmentation_type_id of a segmentation_descriptor
+type SegmentationType uint8
+
+// Segmentation types
+const (
+	NotIndicated                                SegmentationType = 0x00
+	ContentIdentification                       SegmentationType = 0x01
+	ProgramStart                                SegmentationType = 0x10
+	ProgramEnd                                  SegmentationType = 0x11
+	ProgramEarlyTermination                     SegmentationType = 0x12
+	ProgramBreakaway                            SegmentationType = 0x13
+	ProgramResumption                           SegmentationType = 0x14
+	ProgramRunoverPlanned                       SegmentationType = 0x15
+	ProgramRunoverUnplanned                     SegmentationType = 0x16
+	ProgramOverlapStart                         SegmentationType = 0x17
+	ProgramBlackoutOverride                     SegmentationType = 0x18
+	ProgramJoin                                 SegmentationType = 0x19
+	ChapterStart                                SegmentationType = 0x20
+	ChapterEnd                                  SegmentationType = 0x21
+	BreakStart                                  SegmentationType = 0x22
+	BreakEnd                                    SegmentationType = 0x23
+	OpeningCreditStart                          SegmentationType = 0x24
+	OpeningCreditEnd                            SegmentationType = 0x25
+	ClosingCreditStart                          SegmentationType = 0x26
+	ClosingCreditEnd                            SegmentationType = 0x27
+	ProviderAdStart                             SegmentationType = 0x30
+	ProviderAdEnd                               SegmentationType = 0x31
+	DistributorAdStart                          SegmentationType = 0x32
+	DistributorAdEnd                            SegmentationType = 0x33
+	ProviderPlacementOpportunityStart           SegmentationType = 0x34
+	ProviderPlacementOpportunityEnd             SegmentationType = 0x35
+	DistributorPlacementOpportunityStart        SegmentationType = 0x36
+	DistributorPlacementOpportunityEnd          SegmentationType = 0x37
+	ProviderOverlayPlacementOpportunityStart    SegmentationType = 0x38
+	ProviderOverlayPlacementOpportunityEnd      SegmentationType = 0x39
+	DistributorOverlayPlacementOpportunityStart SegmentationType = 0x3A
+	DistributorOverlayPlacementOpportunityEnd   SegmentationType = 0x3B
+	ProviderPromoStart                          SegmentationType = 0x3C
+	ProviderPromoEnd                            SegmentationType = 0x3D
+	DistributorPromoStart                       SegmentationType = 0x3E
+	DistributorPromoEnd                         SegmentationType = 0x3F
+	UnscheduledEventStart                       SegmentationType = 0x40
+	UnscheduledEventEnd                         SegmentationType = 0x41
+	AlternateContentOpportunityStart            SegmentationType = 0x42
+	AlternateContentOpportunityEnd              SegmentationType = 0x43
+	ProviderAdBlockStart                        SegmentationType = 0x44
+	ProviderAdBlockEnd                          SegmentationType = 0x45
+	DistributorAdBlockStart                     SegmentationType = 0x46
+	DistributorAdBlockEnd                       SegmentationType = 0x47
+	NetworkStart                                SegmentationType = 0x50
+	NetworkEnd                                  SegmentationType = 0x51
+)
+
+var segmentationTypeNames = map[SegmentationType]string{
+	NotIndicated:                                "NotIndicated",
+	ContentIdentification:                       "ContentIdentification",
+	ProgramStart:                                "ProgramStart",
+	ProgramEnd:                                  "ProgramEnd",
+	ProgramEarlyTermination:                     "ProgramEarlyTermination",
+	ProgramBreakaway:                            "ProgramBreakaway",
+	ProgramResumption:                           "ProgramResumption",
+	ProgramRunoverPlanned:                       "ProgramRunoverPlanned",
+	ProgramRunoverUnplanned:                     "ProgramRunoverUnplanned",
+	ProgramOverlapStart:                         "ProgramOverlapStart",
+	ProgramBlackoutOverride:                     "ProgramBlackoutOverride",
+	ProgramJoin:                                 "ProgramJoin",
+	ChapterStart:                                "ChapterStart",
+	ChapterEnd:                                  "ChapterEnd",
+	BreakStart:                                  "BreakStart",
+	BreakEnd:                                    "BreakEnd",
+	OpeningCreditStart:                          "OpeningCreditStart",
+	OpeningCreditEnd:                            "OpeningCreditEnd",
+	ClosingCreditStart:                          "ClosingCreditStart",
+	ClosingCreditEnd:                            "ClosingCreditEnd",
+	ProviderAdStart:                             "ProviderAdStart",
+	ProviderAdEnd:                               "ProviderAdEnd",
+	DistributorAdStart:                          "DistributorAdStart",
+	DistributorAdEnd:                            "DistributorAdEnd",
+	ProviderPlacementOpportunityStart:           "ProviderPlacementOpportunityStart",
+	ProviderPlacementOpportunityEnd:             "ProviderPlacementOpportunityEnd",
+	DistributorPlacementOpportunityStart:        "DistributorPlacementOpportunityStart",
+	DistributorPlacementOpportunityEnd:          "DistributorPlacementOpportunityEnd",
+	ProviderOverlayPlacementOpportunityStart:    "ProviderOverlayPlacementOpportunityStart",
+	ProviderOverlayPlacementOpportunityEnd:      "ProviderOverlayPlacementOpportunityEnd",
+	DistributorOverlayPlacementOpportunityStart: "DistributorOverlayPlacementOpportunityStart",
+	DistributorOverlayPlacementOpportunityEnd:   "DistributorOverlayPlacementOpportunityEnd",
+	ProviderPromoStart:                          "ProviderPromoStart",
+	ProviderPromoEnd:                            "ProviderPromoEnd",
+	DistributorPromoStart:                       "DistributorPromoStart",
+	DistributorPromoEnd:                         "DistributorPromoEnd",
+	UnscheduledEventStart:                       "UnscheduledEventStart",
+	UnscheduledEventEnd:                         "UnscheduledEventEnd",
+	AlternateContentOpportunityStart:            "AlternateContentOpportunityStart",
+	AlternateContentOpportunityEnd:              "AlternateContentOpportunityEnd",
+	ProviderAdBlockStart:                        "ProviderAdBlockStart",
+	ProviderAdBlockEnd:                          "ProviderAdBlockEnd",
+	DistributorAdBlockStart:                     "DistributorAdBlockStart",
+	DistributorAdBlockEnd:                       "DistributorAdBlockEnd",
+	NetworkStart:                                "NetworkStart",
+	NetworkEnd:                                  "NetworkEnd",
+}
+
+// String returns the name of the segmentation type
+func (t SegmentationType) String() string {
+	if name, ok := segmentationTypeNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("SegmentationType(0x%02X)", uint8(t))
+}
+
+// Hex returns the segmentation type as written in the TYPE attribute of #EXT-X-SCTE35 (e.g. 0x30)
+func (t SegmentationType) Hex() string {
+	return fmt.Sprintf("0x%x", uint8(t))
+}
+
+// ParseSegmentationType parses a segmentation type from its name or its number (e.g. 0x30 or 48)
+func ParseSegmentationType(text string) (SegmentationType, error) {
+	for t, name := range segmentationTypeNames {
+		if name == text {
+			return t, nil
+		}
+	}
+	v, err := strconv.ParseUint(text, 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrSegmentationTypeInvalid, text)
+	}
+
+	return SegmentationType(v), nil
+}
+
+// IsStart reports if the segmentation type starts a segment (program, chapter, break, advertisement etc.)
+func (t SegmentationType) IsStart() bool {
+	return strings.HasSuffix(t.String(), "Start")
+}
+
+// IsEnd reports if the segmentation type ends a segment (including early termination)
+func (t SegmentationType) IsEnd() bool {
+	return strings.HasSuffix(t.String(), "End") || t == ProgramEarlyTermination
+}
+
+// HasSubSegments reports if the segmentation type carries sub_segment_num and sub_segments_expected
+func (t SegmentationType) HasSubSegments() bool {
+	switch t {
+	case ProviderPlacementOpportunityStart, DistributorPlacementOpportunityStart,
+		ProviderOverlayPlacementOpportunityStart, DistributorOverlayPlacementOpportunityStart,
+		ProviderAdBlockStart, DistributorAdBlockStart:
+		return true
+	}
+
+	return false
+}
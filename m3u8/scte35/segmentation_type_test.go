@@ -0,0 +1,36 @@
+package scte35
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentationType(t *testing.T) {
+	assert.Equal(t, "ProviderAdStart", ProviderAdStart.String())
+	assert.Equal(t, "DistributorPlacementOpportunityEnd", DistributorPlacementOpportunityEnd.String())
+	assert.Equal(t, "SegmentationType(0xFF)", SegmentationType(0xFF).String())
+	assert.Equal(t, "0x30", ProviderAdStart.Hex())
+	assert.Equal(t, "0x1", ContentIdentification.Hex())
+
+	for text, expected := range map[string]SegmentationType{
+		"ProgramStart": ProgramStart,
+		"0x31":         ProviderAdEnd,
+		"0x1":          ContentIdentification,
+		"52":           ProviderPlacementOpportunityStart,
+	} {
+		st, err := ParseSegmentationType(text)
+		require.NoError(t, err, text)
+		assert.Equal(t, expected, st, text)
+	}
+	_, err := ParseSegmentationType("0x100")
+	assert.True(t, errors.Is(err, ErrSegmentationTypeInvalid))
+
+	assert.True(t, ProviderAdStart.IsStart())
+	assert.False(t, ProviderAdStart.IsEnd())
+	assert.True(t, ProgramEarlyTermination.IsEnd())
+	assert.True(t, DistributorPlacementOpportunityStart.HasSubSegments())
+	assert.False(t, ProviderAdStart.HasSubSegments())
+}
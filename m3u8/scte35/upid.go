@@ -0,0 +1,191 @@
+package scte35
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// UPIDType represents the segmentation_upid_type of a segmentation_descriptor
+type UPIDType uint8
+
+// UPID types
+const (
+	UPIDNotUsed        UPIDType = 0x00
+	UPIDUserDefined    UPIDType = 0x01
+	UPIDISCI           UPIDType = 0x02
+	UPIDAdID           UPIDType = 0x03
+	UPIDUMID           UPIDType = 0x04
+	UPIDISANDeprecated UPIDType = 0x05
+	UPIDISAN           UPIDType = 0x06
+	UPIDTID            UPIDType = 0x07
+	UPIDAiringID       UPIDType = 0x08
+	UPIDADI            UPIDType = 0x09
+	UPIDEIDR           UPIDType = 0x0A
+	UPIDATSC           UPIDType = 0x0B
+	UPIDMPU            UPIDType = 0x0C
+	UPIDMID            UPIDType = 0x0D
+	UPIDADSInformation UPIDType = 0x0E
+	UPIDURI            UPIDType = 0x0F
+	UPIDUUID           UPIDType = 0x10
+	UPIDSCR            UPIDType = 0x11
+)
+
+var upidTypeNames = map[UPIDType]string{
+	UPIDNotUsed:        "NotUsed",
+	UPIDUserDefined:    "UserDefined",
+	UPIDISCI:           "ISCI",
+	UPIDAdID:           "AdID",
+	UPIDUMID:           "UMID",
+	UPIDISANDeprecated: "ISANDeprecated",
+	UPIDISAN:           "ISAN",
+	UPIDTID:            "TID",
+	UPIDAiringID:       "AiringID",
+	UPIDADI:            "ADI",
+	UPIDEIDR:           "EIDR",
+	UPIDATSC:           "ATSC",
+	UPIDMPU:            "MPU",
+	UPIDMID:            "MID",
+	UPIDADSInformation: "ADSInformation",
+	UPIDURI:            "URI",
+	UPIDUUID:           "UUID",
+	UPIDSCR:            "SCR",
+}
+
+// String returns the name of the UPID type
+func (t UPIDType) String() string {
+	if name, ok := upidTypeNames[t]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("UPIDType(0x%02X)", uint8(t))
+}
+
+// UPID represents a segmentation_upid along with its type
+type UPID struct {
+	Type UPIDType
+	Data []byte
+	// text is the text the UPID was parsed from, written back as is while Type and Data are unchanged
+	text string
+}
+
+// ParseUPID parses the text representation of a UPID used by #EXT-X-SCTE35 (e.g. 0x0E:0x706561636F636B),
+// the prefixes and the hexadecimal digits are case-insensitive
+func ParseUPID(text string) (*UPID, error) {
+	parts := strings.SplitN(text, ":", 2)
+	if len(parts) != 2 || !hasHexPrefix(parts[0]) || !hasHexPrefix(parts[1]) {
+		return nil, fmt.Errorf("%w: %s", ErrUPIDInvalid, text)
+	}
+	t, err := strconv.ParseUint(parts[0][2:], 16, 8)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUPIDInvalid, text)
+	}
+	data, err := hex.DecodeString(parts[1][2:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUPIDInvalid, text)
+	}
+
+	return &UPID{Type: UPIDType(t), Data: data, text: text}, nil
+}
+
+// hasHexPrefix reports if s starts with 0x or 0X
+func hasHexPrefix(s string) bool {
+	return len(s) >= 2 && strings.EqualFold(s[:2], "0x")
+}
+
+// String returns the text representation of the UPID used by #EXT-X-SCTE35:
+// the text it was parsed from when unchanged, uppercase hexadecimal otherwise
+func (u *UPID) String() string {
+	if u.text != "" {
+		if parsed, err := ParseUPID(u.text); err == nil && parsed.Type == u.Type && bytes.Equal(parsed.Data, u.Data) {
+			return u.text
+		}
+	}
+
+	return fmt.Sprintf("0x%02X:0x%X", uint8(u.Type), u.Data)
+}
+
+// Value returns the UPID decoded according to its type:
+// the text of ISCI, Ad-ID, TID, ADI, ADS information, URI, SCR and the private data of MPU,
+// the EIDR as 10.prefix/XXXX-XXXX-XXXX-XXXX-XXXX and the ISAN as XXXX-XXXX-... (without check characters),
+// the UUID in its canonical form, the others in hexadecimal (0x...)
+func (u *UPID) Value() string {
+	switch u.Type {
+	case UPIDISCI, UPIDAdID, UPIDTID, UPIDADI, UPIDADSInformation, UPIDURI, UPIDSCR:
+		return textOrHex(u.Data)
+	case UPIDMPU:
+		if len(u.Data) >= 4 {
+			return textOrHex(u.Data[4:])
+		}
+	case UPIDEIDR:
+		if len(u.Data) == 12 {
+			return fmt.Sprintf("10.%d/%s", uint16(u.Data[0])<<8|uint16(u.Data[1]), hexGroups(u.Data[2:]))
+		}
+	case UPIDISAN, UPIDISANDeprecated:
+		return hexGroups(u.Data)
+	case UPIDUUID:
+		if len(u.Data) == 16 {
+			s := hex.EncodeToString(u.Data)
+			return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+		}
+	}
+
+	return fmt.Sprintf("0x%X", u.Data)
+}
+
+// FormatIdentifier returns the format_identifier of a MPU UPID, empty for the other types
+func (u *UPID) FormatIdentifier() string {
+	if u.Type != UPIDMPU || len(u.Data) < 4 {
+		return ""
+	}
+
+	return string(u.Data[:4])
+}
+
+// MID returns the UPIDs of a MID UPID
+func (u *UPID) MID() ([]*UPID, error) {
+	if u.Type != UPIDMID {
+		return nil, fmt.Errorf("%w: %s is not a MID", ErrUPIDInvalid, u.Type)
+	}
+
+	var result []*UPID
+	for data := u.Data; len(data) > 0; {
+		if len(data) < 2 || len(data) < 2+int(data[1]) {
+			return nil, fmt.Errorf("%w: truncated MID", ErrUPIDInvalid)
+		}
+		result = append(result, &UPID{Type: UPIDType(data[0]), Data: data[2 : 2+int(data[1])]})
+		data = data[2+int(data[1]):]
+	}
+
+	return result, nil
+}
+
+// textOrHex returns data as text when printable, in hexadecimal (0x...) otherwise
+func textOrHex(data []byte) string {
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("0x%X", data)
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) {
+			return fmt.Sprintf("0x%X", data)
+		}
+	}
+
+	return string(data)
+}
+
+// hexGroups returns data in uppercase hexadecimal by groups of 4 digits separated by dashes
+func hexGroups(data []byte) string {
+	s := strings.ToUpper(hex.EncodeToString(data))
+	groups := make([]string, 0, len(s)/4+1)
+	for len(s) > 4 {
+		groups = append(groups, s[:4])
+		s = s[4:]
+	}
+
+	return strings.Join(append(groups, s), "-")
+}
@@ -0,0 +1,79 @@
+package scte35
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUPID(t *testing.T) {
+	for _, text := range []string{
+		"0x0E:0x706561636F636B5F373033303639",
+		"0x0e:0x50434b5f50434b5f564f445f39303030303832393937",
+		"0x0C:0x4E4243557B7D",
+		"0x00:0x",
+		"0X0E:0X70",
+		"0x0e:0x706561636F636b",
+		"0xE:0x70",
+	} {
+		upid, err := ParseUPID(text)
+		require.NoError(t, err, text)
+		assert.Equal(t, text, upid.String())
+	}
+
+	upid, err := ParseUPID("0x0E:0x706561636F636B5F373033303639")
+	require.NoError(t, err)
+	assert.Equal(t, UPIDADSInformation, upid.Type)
+	assert.Equal(t, "peacock_703069", upid.Value())
+
+	// a modified UPID is written anew
+	upid, err = ParseUPID("0xe:0x7061")
+	require.NoError(t, err)
+	upid.Data = []byte("pb")
+	assert.Equal(t, "0x0E:0x7062", upid.String())
+
+	for _, text := range []string{"", "0x0E", "0E:0x70", "0x0E:70", "0x100:0x70", "0x0E:0x7"} {
+		_, err := ParseUPID(text)
+		assert.True(t, errors.Is(err, ErrUPIDInvalid), text)
+	}
+}
+
+func TestUPID_Value(t *testing.T) {
+	tests := []struct {
+		upid  UPID
+		value string
+	}{
+		{UPID{Type: UPIDAdID, Data: []byte("ABCD0123456H")}, "ABCD0123456H"},
+		{UPID{Type: UPIDADI, Data: []byte("PREVIEW:provider.com/MOVE1234567890123456")}, "PREVIEW:provider.com/MOVE1234567890123456"},
+		{UPID{Type: UPIDURI, Data: []byte("urn:uuid:f81d4fae")}, "urn:uuid:f81d4fae"},
+		{UPID{Type: UPIDMPU, Data: []byte(`NBCU{"key":"pb"}`)}, `{"key":"pb"}`},
+		{UPID{Type: UPIDISAN, Data: []byte{0x00, 0x00, 0x00, 0x00, 0x3A, 0x8D, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}}, "0000-0000-3A8D-0000-0000-0000"},
+		{UPID{Type: UPIDEIDR, Data: []byte{0x14, 0x78, 0xF8, 0x5A, 0xE1, 0x00, 0xB0, 0x68, 0x5B, 0x8F, 0x3A, 0x4A}}, "10.5240/F85A-E100-B068-5B8F-3A4A"},
+		{UPID{Type: UPIDUUID, Data: []byte{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}}, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"},
+		{UPID{Type: UPIDAiringID, Data: []byte{0x2C, 0xA0, 0xA1, 0x8A}}, "0x2CA0A18A"},
+		{UPID{Type: UPIDADSInformation, Data: []byte{0x00, 0xFF}}, "0x00FF"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.value, test.upid.Value(), test.upid.Type.String())
+	}
+
+	mpu := UPID{Type: UPIDMPU, Data: []byte(`NBCU{"key":"pb"}`)}
+	assert.Equal(t, "NBCU", mpu.FormatIdentifier())
+	assert.Equal(t, "", (&UPID{Type: UPIDURI, Data: []byte("NBCU")}).FormatIdentifier())
+	assert.Equal(t, "MPU", mpu.Type.String())
+	assert.Equal(t, "UPIDType(0xFF)", UPIDType(0xFF).String())
+}
+
+func TestUPID_MID(t *testing.T) {
+	mid := &UPID{Type: UPIDMID, Data: []byte{0x03, 0x02, 'A', 'B', 0x0E, 0x01, 'C'}}
+	upids, err := mid.MID()
+	require.NoError(t, err)
+	assert.Equal(t, []*UPID{{Type: UPIDAdID, Data: []byte("AB")}, {Type: UPIDADSInformation, Data: []byte("C")}}, upids)
+
+	_, err = (&UPID{Type: UPIDMID, Data: []byte{0x03, 0x05, 'A'}}).MID()
+	assert.True(t, errors.Is(err, ErrUPIDInvalid))
+	_, err = (&UPID{Type: UPIDURI}).MID()
+	assert.True(t, errors.Is(err, ErrUPIDInvalid))
+}
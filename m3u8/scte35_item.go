@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/scte35"
 )
 
 // SCTE35Item #EXT-X-SCTE35
@@ -20,13 +21,13 @@ type SCTE35Item struct {
 	CueOut     *string
 	CueIn      *string
 	Segne      *string
-	attributes map[string]string
+	attributes parser.AttributeList
 }
 
 func NewSCTE35Item(text string) (*SCTE35Item, error) {
 	attributes := parser.ParseAttributes(text)
 
-	defer deleteKeys(attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		CueTag,
 		DurationTag,
 		ElapsedAttribute,
@@ -53,7 +54,7 @@ func NewSCTE35Item(text string) (*SCTE35Item, error) {
 		CueOut:     parser.PointerTo(attributes, CueOutAttribute),
 		CueIn:      parser.PointerTo(attributes, CueInAttribute),
 		Segne:      parser.PointerTo(attributes, SegneAttribute),
-		attributes: attributes,
+		attributes: unknown,
 	}, nil
 
 }
@@ -101,7 +102,60 @@ func (i *SCTE35Item) String() string {
 		attributes = append(attributes, fmt.Sprintf(parser.QuotedFormatString, SegneAttribute, *i.Segne))
 	}
 
-	attributes = attributesJoinMap(attributes, i.attributes)
+	attributes = attributesJoin(attributes, i.attributes)
 
 	return fmt.Sprintf("%s:%s", SCTE35Tag, strings.Join(attributes, ","))
 }
+
+// SpliceInfo decodes the splice_info_section of the CUE (base64)
+func (i *SCTE35Item) SpliceInfo() (*scte35.SpliceInfoSection, error) {
+	return scte35.DecodeBase64(i.Cue)
+}
+
+// SetSpliceInfo sets the CUE to a splice_info_section encoded in base64
+func (i *SCTE35Item) SetSpliceInfo(section *scte35.SpliceInfoSection) error {
+	cue, err := section.Base64()
+	if err != nil {
+		return err
+	}
+	i.Cue = cue
+
+	return nil
+}
+
+// SegmentationType returns the TYPE, nil when not set or out of the range of segmentation_type_id (0 to 255),
+// types unknown to the scte35 package included
+func (i *SCTE35Item) SegmentationType() *scte35.SegmentationType {
+	if i.Type == nil || *i.Type < 0 || *i.Type > 0xFF {
+		return nil
+	}
+
+	t := scte35.SegmentationType(*i.Type)
+	return &t
+}
+
+// SetSegmentationType sets the TYPE
+func (i *SCTE35Item) SetSegmentationType(t scte35.SegmentationType) {
+	v := int(t)
+	i.Type = &v
+}
+
+// TypedUPID parses the UPID, nil when not set
+func (i *SCTE35Item) TypedUPID() (*scte35.UPID, error) {
+	if i.UPID == nil {
+		return nil, nil
+	}
+
+	return scte35.ParseUPID(*i.UPID)
+}
+
+// SetUPID sets the UPID, nil removes it
+func (i *SCTE35Item) SetUPID(upid *scte35.UPID) {
+	if upid == nil {
+		i.UPID = nil
+		return
+	}
+
+	text := upid.String()
+	i.UPID = &text
+}
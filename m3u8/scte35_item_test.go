@@ -1,11 +1,16 @@
 package m3u8
 
 import (
+	"errors"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/scte35"
 )
 
 func TestNewSCTE35Item(t *testing.T) {
@@ -41,10 +46,125 @@ func TestNewSCTE35Item(t *testing.T) {
 	require.Equal(t, pointer.ToString("YES"), item.CueOut)
 	require.Equal(t, pointer.ToString("YES"), item.CueIn)
 	require.Equal(t, pointer.ToString("3:3"), item.Segne)
-	require.Equal(t, "\"RANDOM-VALUE\"", item.attributes["RANDOM-ATTRIBUTE"])
+	randomValue, _ := item.attributes.Get("RANDOM-ATTRIBUTE")
+	require.Equal(t, "\"RANDOM-VALUE\"", randomValue)
 
 	itemEncoded := item.String()
 	for _, attributeKV := range attributesKV {
 		require.True(t, strings.Contains(itemEncoded, attributeKV), attributeKV, itemEncoded)
 	}
 }
+
+func TestSCTE35Item_SpliceInfo(t *testing.T) {
+	pl, err := ReadFile("fixtures/fer_with_ads.m3u8")
+	require.NoError(t, err)
+
+	count := 0
+	var typeMismatches []string
+	for _, item := range pl.Items {
+		si, ok := item.(*SCTE35Item)
+		if !ok {
+			continue
+		}
+		count++
+
+		section, err := si.SpliceInfo()
+		require.NoError(t, err, si.Cue)
+		descriptors := section.SegmentationDescriptors()
+		require.Len(t, descriptors, 1, si.Cue)
+		d := descriptors[0]
+
+		require.NotNil(t, si.Type)
+		if *si.Type != int(d.TypeID) {
+			typeMismatches = append(typeMismatches, si.SegmentationType().Hex()+":"+d.TypeID.Hex())
+		}
+		require.NotNil(t, si.UPID)
+		assert.Equal(t, *si.UPID, d.SegmentationUPID().String())
+		require.NotNil(t, si.ID)
+		assert.Equal(t, *si.ID, strconv.Itoa(int(d.EventID)))
+		if si.Duration != nil {
+			require.NotNil(t, d.Duration)
+			assert.InDelta(t, *si.Duration, *d.DurationSeconds(), 0.0005)
+		}
+
+		encoded := &SCTE35Item{}
+		require.NoError(t, encoded.SetSpliceInfo(section))
+		assert.Equal(t, si.Cue, encoded.Cue)
+	}
+	assert.NotZero(t, count)
+	// the second program end (TYPE=0x11) of the fixture carries the cue of the program start
+	assert.Equal(t, []string{"0x11:0x10"}, typeMismatches)
+
+	_, err = (&SCTE35Item{Cue: "invalid"}).SpliceInfo()
+	assert.True(t, errors.Is(err, scte35.ErrSectionInvalid))
+}
+
+func TestSCTE35Item_SetSpliceInfo(t *testing.T) {
+	d := scte35.NewSegmentationDescriptor(9, scte35.DistributorPlacementOpportunityStart, 0x0E, []byte("ad_opportunity"))
+	d.Duration = pointer.ToUint64(scte35.Ticks(30))
+	section := scte35.NewSpliceInfoSection(&scte35.TimeSignal{SpliceTime: pointer.ToUint64(900000)}, d)
+
+	item := &SCTE35Item{Type: pointer.ToInt(int(d.TypeID)), Duration: pointer.ToFloat64(30)}
+	require.NoError(t, item.SetSpliceInfo(section))
+
+	parsed, err := NewSCTE35Item(item.String())
+	require.NoError(t, err)
+	decoded, err := parsed.SpliceInfo()
+	require.NoError(t, err)
+	assert.Equal(t, scte35.DistributorPlacementOpportunityStart, decoded.SegmentationDescriptors()[0].TypeID)
+	assert.Equal(t, pointer.ToUint64(900000), decoded.PTS())
+
+	section.SpliceCommand = nil
+	assert.Error(t, item.SetSpliceInfo(section))
+}
+
+func TestSCTE35Item_TypedUPID(t *testing.T) {
+	pl, err := ReadFile("fixtures/fer_with_ads.m3u8")
+	require.NoError(t, err)
+	lines := []string{"#EXT-X-SCTE35:CUE=\"\",TYPE=0x50,UPID=\"0x0e:0x50434b5f50434b5f564f445f39303030303832393937\""}
+	for _, item := range pl.Items {
+		if si, ok := item.(*SCTE35Item); ok {
+			lines = append(lines, si.String())
+		}
+	}
+
+	for _, line := range lines {
+		item, err := NewSCTE35Item(line)
+		require.NoError(t, err)
+		upid, err := item.TypedUPID()
+		require.NoError(t, err)
+		st := item.SegmentationType()
+		require.NotNil(t, st)
+
+		item.SetUPID(upid)
+		item.SetSegmentationType(*st)
+		assert.Equal(t, line, item.String())
+	}
+
+	item, err := NewSCTE35Item(lines[1])
+	require.NoError(t, err)
+	upid, err := item.TypedUPID()
+	require.NoError(t, err)
+	assert.Equal(t, scte35.UPIDADSInformation, upid.Type)
+	assert.Equal(t, "peacock_703069", upid.Value())
+	assert.Equal(t, scte35.ProgramEnd, *item.SegmentationType())
+
+	item.SetUPID(&scte35.UPID{Type: scte35.UPIDAdID, Data: []byte("ABCD0123456H")})
+	item.SetSegmentationType(scte35.ProviderAdStart)
+	assert.Contains(t, item.String(), `TYPE=0x30,UPID="0x03:0x414243443031323334353648"`)
+
+	item.SetUPID(nil)
+	upid, err = item.TypedUPID()
+	assert.NoError(t, err)
+	assert.Nil(t, upid)
+
+	item.UPID = pointer.ToString("peacock")
+	_, err = item.TypedUPID()
+	assert.True(t, errors.Is(err, scte35.ErrUPIDInvalid))
+
+	item.Type = pointer.ToInt(0x100)
+	assert.Nil(t, item.SegmentationType())
+	// types unknown to the scte35 package are returned as well
+	item.Type = pointer.ToInt(0x99)
+	assert.Equal(t, scte35.SegmentationType(0x99), *item.SegmentationType())
+}
@@ -8,12 +8,16 @@ import (
 
 // SegmentItem represents EXTINF attributes with the URI that follows,
 // optionally allowing an EXT-X-BYTERANGE tag to be set.
+// Parts holds the EXT-X-PART tags preceding the segment (LL-HLS),
+// Gap is set when the segment is marked with EXT-X-GAP.
 type SegmentItem struct {
 	Duration        float64
 	Segment         string
 	Comment         *string // title
 	ProgramDateTime *TimeItem
 	ByteRange       *ByteRange
+	Parts           []*PartItem
+	Gap             bool
 	attributes      map[string]string
 }
 
@@ -36,6 +40,10 @@ func NewSegmentItem(text string) (*SegmentItem, error) {
 }
 
 func (si *SegmentItem) String() string {
+	parts := ""
+	for _, part := range si.Parts {
+		parts += fmt.Sprintf("%v\n", part)
+	}
 	date := ""
 	if si.ProgramDateTime != nil {
 		date = fmt.Sprintf("%v\n", si.ProgramDateTime)
@@ -45,10 +53,15 @@ func (si *SegmentItem) String() string {
 		byteRange = fmt.Sprintf("\n%s:%v", ByteRangeItemTag, si.ByteRange.String())
 	}
 
+	gap := ""
+	if si.Gap {
+		gap = fmt.Sprintf("\n%s", GapItemTag)
+	}
+
 	comment := ""
 	if si.Comment != nil {
 		comment = *si.Comment
 	}
 
-	return fmt.Sprintf("%s:%v,%s%s\n%s%s", SegmentItemTag, si.Duration, comment, byteRange, date, si.Segment)
+	return fmt.Sprintf("%s%s:%v,%s%s%s\n%s%s", parts, SegmentItemTag, si.Duration, comment, byteRange, gap, date, si.Segment)
 }
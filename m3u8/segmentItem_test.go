@@ -51,4 +51,23 @@ func TestSegmentItem_Parse(t *testing.T) {
 	}
 
 	assert.Equal(t, "#EXTINF:10.991,anything\n#EXT-X-BYTERANGE:4500\ntest.ts", item.String())
+
+	item = &SegmentItem{
+		Duration: 1,
+		Segment:  "test.ts",
+		Parts: []*PartItem{
+			{Duration: 0.5, URI: "test.0.ts", Independent: pointer.ToBool(true)},
+			{Duration: 0.5, URI: "test.1.ts"},
+		},
+	}
+
+	assert.Equal(t, "#EXT-X-PART:DURATION=0.5,URI=\"test.0.ts\",INDEPENDENT=YES\n#EXT-X-PART:DURATION=0.5,URI=\"test.1.ts\"\n#EXTINF:1,\ntest.ts", item.String())
+
+	item = &SegmentItem{
+		Duration: 10.991,
+		Segment:  "test.ts",
+		Gap:      true,
+	}
+
+	assert.Equal(t, "#EXTINF:10.991,\n#EXT-X-GAP\ntest.ts", item.String())
 }
@@ -0,0 +1,97 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// ServerControl represents a #EXT-X-SERVER-CONTROL tag which allows the server
+// to indicate support for delivery directives (LL-HLS)
+type ServerControl struct {
+	CanSkipUntil      *float64
+	CanSkipDateRanges *bool
+	HoldBack          *float64
+	PartHoldBack      *float64
+	CanBlockReload    *bool
+	attributes        parser.AttributeList
+}
+
+// NewServerControl parses a text line and returns a *ServerControl
+func NewServerControl(text string) (*ServerControl, error) {
+	attributes := parser.ParseAttributes(text)
+
+	canSkipUntil, err := parser.ParseFloat(attributes, CanSkipUntilTag)
+	if err != nil {
+		return nil, err
+	}
+	holdBack, err := parser.ParseFloat(attributes, HoldBackTag)
+	if err != nil {
+		return nil, err
+	}
+	partHoldBack, err := parser.ParseFloat(attributes, PartHoldBackTag)
+	if err != nil {
+		return nil, err
+	}
+
+	unknown := parser.ParseAttributeList(text).Without(
+		CanSkipUntilTag,
+		CanSkipDateRangesTag,
+		HoldBackTag,
+		PartHoldBackTag,
+		CanBlockReloadTag,
+	)
+
+	return &ServerControl{
+		CanSkipUntil:      canSkipUntil,
+		CanSkipDateRanges: parser.ParseYesNo(attributes, CanSkipDateRangesTag),
+		HoldBack:          holdBack,
+		PartHoldBack:      partHoldBack,
+		CanBlockReload:    parser.ParseYesNo(attributes, CanBlockReloadTag),
+		attributes:        unknown,
+	}, nil
+}
+
+func (sc *ServerControl) String() string {
+	var slice []string
+
+	if sc.CanBlockReload != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, CanBlockReloadTag, parser.FormatYesNo(*sc.CanBlockReload)))
+	}
+	if sc.CanSkipUntil != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, CanSkipUntilTag, *sc.CanSkipUntil))
+	}
+	if sc.CanSkipDateRanges != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, CanSkipDateRangesTag, parser.FormatYesNo(*sc.CanSkipDateRanges)))
+	}
+	if sc.HoldBack != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, HoldBackTag, *sc.HoldBack))
+	}
+	if sc.PartHoldBack != nil {
+		slice = append(slice, fmt.Sprintf(parser.FormatString, PartHoldBackTag, *sc.PartHoldBack))
+	}
+
+	slice = attributesJoin(slice, sc.attributes)
+
+	return fmt.Sprintf("%s:%s", ServerControlTag, strings.Join(slice, ","))
+}
+
+func (sc *ServerControl) Validate() []error {
+	var errs []error
+
+	if sc.CanSkipUntil != nil && *sc.CanSkipUntil <= 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", CanSkipUntilTag))
+	}
+	if sc.CanSkipDateRanges != nil && sc.CanSkipUntil == nil {
+		errs = append(errs, fmt.Errorf("%s attribute requires %s", CanSkipDateRangesTag, CanSkipUntilTag))
+	}
+	if sc.HoldBack != nil && *sc.HoldBack < 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", HoldBackTag))
+	}
+	if sc.PartHoldBack != nil && *sc.PartHoldBack < 0 {
+		errs = append(errs, fmt.Errorf("%s attribute is not valid", PartHoldBackTag))
+	}
+
+	return errs
+}
@@ -0,0 +1,48 @@
+package m3u8
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerControl_Parse(t *testing.T) {
+	line := `#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,CAN-SKIP-UNTIL=36,CAN-SKIP-DATERANGES=YES,HOLD-BACK=12,PART-HOLD-BACK=1.002`
+
+	sc, err := NewServerControl(line)
+	assert.Nil(t, err)
+	assertNotNilEqual(t, true, sc.CanBlockReload)
+	assertNotNilEqual(t, 36.0, sc.CanSkipUntil)
+	assertNotNilEqual(t, true, sc.CanSkipDateRanges)
+	assertNotNilEqual(t, 12.0, sc.HoldBack)
+	assertNotNilEqual(t, 1.002, sc.PartHoldBack)
+	assert.Nil(t, sc.Validate())
+
+	assertToString(t, line, sc)
+}
+
+func TestServerControl_Parse_2(t *testing.T) {
+	line := `#EXT-X-SERVER-CONTROL:HOLD-BACK=9.5`
+
+	sc, err := NewServerControl(line)
+	assert.Nil(t, err)
+	assert.Nil(t, sc.CanBlockReload)
+	assert.Nil(t, sc.CanSkipUntil)
+	assert.Nil(t, sc.PartHoldBack)
+	assertNotNilEqual(t, 9.5, sc.HoldBack)
+
+	assertToString(t, line, sc)
+}
+
+func TestServerControl_Invalid(t *testing.T) {
+	sc, err := NewServerControl(`#EXT-X-SERVER-CONTROL:HOLD-BACK=x`)
+	assert.Error(t, err)
+	assert.Nil(t, sc)
+
+	sc = &ServerControl{
+		CanSkipDateRanges: pointer.ToBool(true),
+		PartHoldBack:      pointer.ToFloat64(-1),
+	}
+	assert.Len(t, sc.Validate(), 2)
+}
@@ -13,15 +13,14 @@ type SessionDataItem struct {
 	Value      *string
 	URI        *string
 	Language   *string
-	attributes map[string]string
+	attributes parser.AttributeList
 }
 
 // NewSessionDataItem parses a text line and returns a *SessionDataItem
 func NewSessionDataItem(text string) *SessionDataItem {
 	attributes := parser.ParseAttributes(text)
 
-	defer deleteKeys(
-		attributes,
+	unknown := parser.ParseAttributeList(text).Without(
 		DataIDTag,
 		ValueTag,
 		URITag,
@@ -33,7 +32,7 @@ func NewSessionDataItem(text string) *SessionDataItem {
 		Value:      parser.PointerTo(attributes, ValueTag),
 		URI:        parser.PointerTo(attributes, URITag),
 		Language:   parser.PointerTo(attributes, LanguageTag),
-		attributes: attributes,
+		attributes: unknown,
 	}
 }
 
@@ -50,7 +49,7 @@ func (sdi *SessionDataItem) String() string {
 		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, LanguageTag, *sdi.Language))
 	}
 
-	slice = attributesJoinMap(slice, sdi.attributes)
+	slice = attributesJoin(slice, sdi.attributes)
 
 	return fmt.Sprintf(`%s:%s`, SessionDataItemTag, strings.Join(slice, ","))
 }
@@ -2,8 +2,6 @@ package m3u8
 
 import (
 	"fmt"
-
-	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
 )
 
 // SessionKeyItem represents a set of EXT-X-SESSION-KEY attributes
@@ -13,9 +11,8 @@ type SessionKeyItem struct {
 
 // NewSessionKeyItem parses a text line and returns a *SessionKeyItem
 func NewSessionKeyItem(text string) *SessionKeyItem {
-	attributes := parser.ParseAttributes(text)
 	return &SessionKeyItem{
-		Encryptable: NewEncryptable(attributes),
+		Encryptable: parseEncryptable(text),
 	}
 }
 
@@ -0,0 +1,70 @@
+package m3u8
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// SkipItem represents a #EXT-X-SKIP tag which replaces the segments
+// skipped in a playlist delta update
+type SkipItem struct {
+	SkippedSegments int
+	// RecentlyRemovedDateRanges is nil if the attribute is absent
+	RecentlyRemovedDateRanges []string
+	attributes                parser.AttributeList
+}
+
+// NewSkipItem parses a text line and returns a *SkipItem
+func NewSkipItem(text string) (*SkipItem, error) {
+	attributes := parser.ParseAttributes(text)
+
+	skippedSegments, err := parser.ParseInt(attributes, SkippedSegmentsTag)
+	if err != nil {
+		return nil, err
+	}
+	if skippedSegments == nil {
+		return nil, ErrSkipItemInvalid
+	}
+
+	var removed []string
+	if value := parser.PointerTo(attributes, RecentlyRemovedDateRangesTag); value != nil {
+		removed = []string{}
+		if *value != "" {
+			removed = strings.Split(*value, "\t")
+		}
+	}
+
+	unknown := parser.ParseAttributeList(text).Without(
+		SkippedSegmentsTag,
+		RecentlyRemovedDateRangesTag,
+	)
+
+	return &SkipItem{
+		SkippedSegments:           *skippedSegments,
+		RecentlyRemovedDateRanges: removed,
+		attributes:                unknown,
+	}, nil
+}
+
+func (si *SkipItem) String() string {
+	slice := []string{fmt.Sprintf(parser.FormatString, SkippedSegmentsTag, si.SkippedSegments)}
+
+	if si.RecentlyRemovedDateRanges != nil {
+		slice = append(slice, fmt.Sprintf(parser.QuotedFormatString, RecentlyRemovedDateRangesTag,
+			strings.Join(si.RecentlyRemovedDateRanges, "\t")))
+	}
+
+	slice = attributesJoin(slice, si.attributes)
+
+	return fmt.Sprintf("%s:%s", SkipItemTag, strings.Join(slice, ","))
+}
+
+func (si *SkipItem) Validate() []error {
+	if si.SkippedSegments <= 0 {
+		return []error{fmt.Errorf("%s attribute is not valid", SkippedSegmentsTag)}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package m3u8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipItem_Parse(t *testing.T) {
+	line := "#EXT-X-SKIP:SKIPPED-SEGMENTS=3,RECENTLY-REMOVED-DATERANGES=\"splice-1\tsplice-2\""
+
+	si, err := NewSkipItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, si.SkippedSegments)
+	assert.Equal(t, []string{"splice-1", "splice-2"}, si.RecentlyRemovedDateRanges)
+	assert.Nil(t, si.Validate())
+
+	assertToString(t, line, si)
+}
+
+func TestSkipItem_Parse_2(t *testing.T) {
+	line := `#EXT-X-SKIP:SKIPPED-SEGMENTS=10`
+
+	si, err := NewSkipItem(line)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, si.SkippedSegments)
+	assert.Nil(t, si.RecentlyRemovedDateRanges)
+
+	assertToString(t, line, si)
+
+	line = `#EXT-X-SKIP:SKIPPED-SEGMENTS=10,RECENTLY-REMOVED-DATERANGES=""`
+	si, err = NewSkipItem(line)
+	assert.Nil(t, err)
+	assert.NotNil(t, si.RecentlyRemovedDateRanges)
+	assert.Empty(t, si.RecentlyRemovedDateRanges)
+
+	assertToString(t, line, si)
+}
+
+func TestSkipItem_Invalid(t *testing.T) {
+	si, err := NewSkipItem(`#EXT-X-SKIP:RECENTLY-REMOVED-DATERANGES=""`)
+	assert.Equal(t, ErrSkipItemInvalid, err)
+	assert.Nil(t, si)
+
+	si = &SkipItem{}
+	assert.Len(t, si.Validate(), 1)
+}
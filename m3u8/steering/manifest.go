@@ -0,0 +1,97 @@
+// Package steering provides the content steering manifest model and a steering server
+package steering
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8"
+)
+
+// Version is the steering manifest version supported by the package
+const Version = 1
+
+var (
+	// ErrManifestInvalid represents error when a steering manifest doesn't comply with the specification
+	ErrManifestInvalid = errors.New("invalid steering manifest")
+
+	// ErrPathwayMissing represents error when a steering manifest refers to a pathway missing in a master playlist
+	ErrPathwayMissing = errors.New("pathway is missing in master playlist")
+)
+
+// Manifest represents a content steering manifest (JSON)
+type Manifest struct {
+	Version         int            `json:"VERSION"`
+	TTL             int            `json:"TTL"`
+	ReloadURI       string         `json:"RELOAD-URI,omitempty"`
+	PathwayPriority []string       `json:"PATHWAY-PRIORITY"`
+	PathwayClones   []PathwayClone `json:"PATHWAY-CLONES,omitempty"`
+}
+
+// PathwayClone represents a pathway created by the client from a base pathway
+type PathwayClone struct {
+	BaseID         string         `json:"BASE-ID"`
+	ID             string         `json:"ID"`
+	URIReplacement URIReplacement `json:"URI-REPLACEMENT"`
+}
+
+// URIReplacement represents the URI modifications of a pathway clone
+type URIReplacement struct {
+	Host             string            `json:"HOST,omitempty"`
+	Params           map[string]string `json:"PARAMS,omitempty"`
+	PerVariantURIs   map[string]string `json:"PER-VARIANT-URIS,omitempty"`
+	PerRenditionURIs map[string]string `json:"PER-RENDITION-URIS,omitempty"`
+}
+
+// Decode reads a steering manifest from an io.Reader
+func Decode(reader io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(reader).Decode(&m); err != nil {
+		return nil, err
+	}
+	if m.Version != Version || m.TTL <= 0 || len(m.PathwayPriority) == 0 {
+		return nil, ErrManifestInvalid
+	}
+
+	return &m, nil
+}
+
+// Encode writes a steering manifest to an io.Writer
+func (m *Manifest) Encode(writer io.Writer) error {
+	return json.NewEncoder(writer).Encode(m)
+}
+
+// Validate checks that every pathway of a steering manifest is either
+// a pathway of a master playlist or a clone of one
+func (m *Manifest) Validate(pl *m3u8.Playlist) error {
+	pathways := make(map[string]bool)
+	for _, id := range pl.PathwayIDs() {
+		pathways[id] = true
+	}
+	for _, clone := range m.PathwayClones {
+		if !pathways[clone.BaseID] {
+			return ErrPathwayMissing
+		}
+		pathways[clone.ID] = true
+	}
+	for _, id := range m.PathwayPriority {
+		if !pathways[id] {
+			return ErrPathwayMissing
+		}
+	}
+
+	return nil
+}
+
+// ApplyClones adds the pathway clones of a steering manifest to a master playlist,
+// applying their URI replacements (see m3u8.Playlist.ClonePathwayWith)
+func (m *Manifest) ApplyClones(pl *m3u8.Playlist) error {
+	for _, clone := range m.PathwayClones {
+		if err := pl.ClonePathwayWith(clone.BaseID, clone.ID, m3u8.URIReplacement(clone.URIReplacement)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
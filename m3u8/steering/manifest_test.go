@@ -0,0 +1,103 @@
+package steering
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8"
+)
+
+const manifestJSON = `{
+  "VERSION": 1,
+  "TTL": 300,
+  "RELOAD-URI": "https://steering.example.com/steering.json?session=abc",
+  "PATHWAY-PRIORITY": ["CDN-C", "CDN-B", "CDN-A"],
+  "PATHWAY-CLONES": [
+    {
+      "BASE-ID": "CDN-A",
+      "ID": "CDN-C",
+      "URI-REPLACEMENT": {
+        "HOST": "cdn-c.example.com",
+        "PARAMS": {"token": "123"}
+      }
+    }
+  ]
+}`
+
+func TestManifest_Decode(t *testing.T) {
+	m, err := Decode(strings.NewReader(manifestJSON))
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Version)
+	assert.Equal(t, 300, m.TTL)
+	assert.Equal(t, "https://steering.example.com/steering.json?session=abc", m.ReloadURI)
+	assert.Equal(t, []string{"CDN-C", "CDN-B", "CDN-A"}, m.PathwayPriority)
+	require.Len(t, m.PathwayClones, 1)
+	assert.Equal(t, "CDN-A", m.PathwayClones[0].BaseID)
+	assert.Equal(t, "CDN-C", m.PathwayClones[0].ID)
+	assert.Equal(t, "cdn-c.example.com", m.PathwayClones[0].URIReplacement.Host)
+	assert.Equal(t, map[string]string{"token": "123"}, m.PathwayClones[0].URIReplacement.Params)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.Encode(&buf))
+	decoded, err := Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, m, decoded)
+}
+
+func TestManifest_Decode_Invalid(t *testing.T) {
+	testCases := []string{
+		`{"VERSION": 2, "TTL": 300, "PATHWAY-PRIORITY": ["CDN-A"]}`,
+		`{"VERSION": 1, "TTL": 0, "PATHWAY-PRIORITY": ["CDN-A"]}`,
+		`{"VERSION": 1, "TTL": 300}`,
+	}
+
+	for _, tc := range testCases {
+		m, err := Decode(strings.NewReader(tc))
+		assert.Equal(t, ErrManifestInvalid, err, tc)
+		assert.Nil(t, m)
+	}
+
+	_, err := Decode(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
+
+func TestManifest_Validate(t *testing.T) {
+	pl, err := m3u8.ReadFile("../fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+
+	m, err := Decode(strings.NewReader(manifestJSON))
+	require.NoError(t, err)
+	assert.NoError(t, m.Validate(pl))
+
+	require.NoError(t, m.ApplyClones(pl))
+	assert.Equal(t, []string{"CDN-A", "CDN-B", "CDN-C"}, pl.PathwayIDs())
+	assert.Equal(t, "https://cdn-c.example.com/video/low.m3u8?token=123", pl.Pathways()["CDN-C"][0].URI)
+
+	m.PathwayPriority = append(m.PathwayPriority, "CDN-D")
+	assert.Equal(t, ErrPathwayMissing, m.Validate(pl))
+}
+
+func TestManifest_ApplyClones(t *testing.T) {
+	pl, err := m3u8.ReadFile("../fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+
+	m := &Manifest{
+		Version:         Version,
+		TTL:             300,
+		PathwayPriority: []string{"CDN-C", "CDN-A"},
+		PathwayClones: []PathwayClone{
+			{BaseID: "CDN-A", ID: "CDN-C", URIReplacement: URIReplacement{Params: map[string]string{"cdn": "c"}}},
+			{BaseID: "CDN-A", ID: "CDN-D", URIReplacement: URIReplacement{Host: "https://cdn-d.example.com"}},
+		},
+	}
+	err = m.ApplyClones(pl)
+	assert.True(t, errors.Is(err, m3u8.ErrHostInvalid), err)
+
+	// the host is kept without HOST
+	assert.Equal(t, "https://cdn-a.example.com/video/low.m3u8?cdn=c", pl.Pathways()["CDN-C"][0].URI)
+}
@@ -0,0 +1,78 @@
+package steering
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// PathwayQueryParam is the query parameter carrying the pathway currently used by the client
+	PathwayQueryParam = "_HLS_pathway"
+	// ThroughputQueryParam is the query parameter carrying the throughput (bits/s) observed by the client
+	ThroughputQueryParam = "_HLS_throughput"
+
+	contentType = "application/json"
+)
+
+// Request represents the client state sent to the steering server
+type Request struct {
+	Pathway    string
+	Throughput *int
+}
+
+// SteerFunc returns the steering manifest for a client request
+type SteerFunc func(req Request) *Manifest
+
+// Server is an http.Handler serving steering manifests
+type Server struct {
+	steer SteerFunc
+}
+
+// NewServer returns a *Server which responds with a steering manifest built by steer
+func NewServer(steer SteerFunc) *Server {
+	return &Server{steer: steer}
+}
+
+// NewStaticServer returns a *Server which always responds with the same steering manifest
+func NewStaticServer(m *Manifest) *Server {
+	return NewServer(func(req Request) *Manifest {
+		return m
+	})
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	req := Request{Pathway: query.Get(PathwayQueryParam)}
+	if value := query.Get(ThroughputQueryParam); value != "" {
+		throughput, err := strconv.Atoi(value)
+		if err != nil || throughput < 0 {
+			http.Error(w, "invalid "+ThroughputQueryParam, http.StatusBadRequest)
+			return
+		}
+		req.Throughput = &throughput
+	}
+
+	m := s.steer(req)
+	if m == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
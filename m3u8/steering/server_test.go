@@ -0,0 +1,122 @@
+package steering
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8"
+)
+
+// steerAwayFrom moves the pathway the client reports to the end of the priority list
+// when its throughput is low
+func steerAwayFrom(priority []string) SteerFunc {
+	return func(req Request) *Manifest {
+		pathways := append([]string(nil), priority...)
+		if req.Throughput != nil && *req.Throughput < 1000000 {
+			pathways = pathways[:0]
+			for _, id := range priority {
+				if id != req.Pathway {
+					pathways = append(pathways, id)
+				}
+			}
+			pathways = append(pathways, req.Pathway)
+		}
+
+		return &Manifest{
+			Version:         Version,
+			TTL:             10,
+			PathwayPriority: pathways,
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	server := httptest.NewServer(NewServer(steerAwayFrom([]string{"CDN-A", "CDN-B"})))
+	defer server.Close()
+
+	// master playlist produced by this package, pointing to the steering server
+	master, err := m3u8.ReadFile("../fixtures/contentSteering.m3u8")
+	require.NoError(t, err)
+	master.ContentSteering().ServerURI = server.URL + "/steering.json"
+	pl, err := m3u8.ReadString(master.String())
+	require.NoError(t, err)
+
+	csi := pl.ContentSteering()
+	require.NotNil(t, csi)
+
+	testCases := []struct {
+		throughput string
+		priority   []string
+	}{
+		{throughput: "5000000", priority: []string{"CDN-A", "CDN-B"}},
+		{throughput: "500000", priority: []string{"CDN-B", "CDN-A"}},
+		{throughput: "", priority: []string{"CDN-A", "CDN-B"}},
+	}
+
+	for _, tc := range testCases {
+		u, err := url.Parse(csi.ServerURI)
+		require.NoError(t, err)
+		query := u.Query()
+		query.Set(PathwayQueryParam, *csi.PathwayID)
+		if tc.throughput != "" {
+			query.Set(ThroughputQueryParam, tc.throughput)
+		}
+		u.RawQuery = query.Encode()
+
+		resp, err := http.Get(u.String())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		m, err := Decode(resp.Body)
+		require.NoError(t, resp.Body.Close())
+		require.NoError(t, err)
+		assert.Equal(t, tc.priority, m.PathwayPriority)
+		assert.NoError(t, m.Validate(pl))
+	}
+}
+
+func TestServer_Static(t *testing.T) {
+	m := &Manifest{
+		Version:         Version,
+		TTL:             300,
+		PathwayPriority: []string{"CDN-A"},
+	}
+	var req Request
+	server := httptest.NewServer(NewServer(func(r Request) *Manifest {
+		req = r
+		return m
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?_HLS_pathway=CDN-B&_HLS_throughput=123")
+	require.NoError(t, err)
+	decoded, err := Decode(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, m, decoded)
+	assert.Equal(t, Request{Pathway: "CDN-B", Throughput: pointer.ToInt(123)}, req)
+
+	resp, err = http.Get(server.URL + "?_HLS_throughput=fast")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(server.URL, "application/json", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	static := httptest.NewServer(NewStaticServer(m))
+	defer static.Close()
+	resp, err = http.Get(static.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
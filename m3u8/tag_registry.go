@@ -0,0 +1,82 @@
+package m3u8
+
+import (
+	"sync"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+// TagDecoder decodes the line of a custom tag into an item
+type TagDecoder func(line string) (Item, error)
+
+// TagRegistry holds decoders of custom (e.g. vendor specific) tags.
+// The reader consults it for the tags not implemented by this lib before falling back to UnknownItem,
+// so registering a tag implemented by this lib has no effect.
+type TagRegistry struct {
+	mu       sync.RWMutex
+	decoders map[string]TagDecoder
+}
+
+// NewTagRegistry returns an empty *TagRegistry
+func NewTagRegistry() *TagRegistry {
+	return &TagRegistry{
+		decoders: make(map[string]TagDecoder),
+	}
+}
+
+// RegisterTag registers the decoder of a tag (e.g. #EXT-X-CUE-OUT), replacing any previous one
+func (r *TagRegistry) RegisterTag(name string, decode func(line string) (Item, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decoders[name] = decode
+}
+
+// decoder returns the decoder of the tag of a line
+func (r *TagRegistry) decoder(line string) (TagDecoder, bool) {
+	if r == nil {
+		return nil, false
+	}
+	name := parser.ParseTagName(line)
+	if name == "" {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	decode, ok := r.decoders[name]
+	return decode, ok
+}
+
+// globalTags is the registry used by all readers
+var globalTags = NewTagRegistry()
+
+// RegisterTag registers the decoder of a custom tag for all readers,
+// the tags registered in ReadOptions.Tags take precedence over it
+func RegisterTag(name string, decode func(line string) (Item, error)) {
+	globalTags.RegisterTag(name, decode)
+}
+
+// readCustomTag decodes a line with the registered decoders,
+// it returns false when the tag of the line is not registered
+func readCustomTag(line string, pl *Playlist, st *state) (bool, error) {
+	decode, ok := st.tags.decoder(line)
+	if !ok {
+		decode, ok = globalTags.decoder(line)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	item, err := decode(line)
+	if err != nil {
+		return true, err
+	}
+	if item == nil {
+		item = NewUnknownItem(line, nil)
+	}
+	pl.Items = append(pl.Items, item)
+
+	return true, nil
+}
@@ -0,0 +1,98 @@
+package m3u8
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cueOutItem struct {
+	Duration float64
+}
+
+func (i *cueOutItem) String() string {
+	return "#EXT-X-CUE-OUT:" + strconv.FormatFloat(i.Duration, 'f', -1, 64)
+}
+
+func decodeCueOut(line string) (Item, error) {
+	d, err := strconv.ParseFloat(strings.TrimPrefix(line, "#EXT-X-CUE-OUT:"), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &cueOutItem{Duration: d}, nil
+}
+
+func TestTagRegistry(t *testing.T) {
+	s := strings.Join([]string{
+		HeaderTag,
+		"#EXT-X-TARGETDURATION:10",
+		"#EXTINF:10,",
+		"segment0.ts",
+		"#EXT-X-CUE-OUT:30",
+		"#EXTINF:10,",
+		"segment1.ts",
+		"#EXT-X-CUE-IN",
+		"#EXTINF:10,",
+		"segment2.ts",
+		"#EXT-X-CUE-OUT:abc",
+	}, "\n")
+
+	// without registry custom tags are unknown items
+	pl, err := Read(strings.NewReader(s))
+	require.NoError(t, err)
+	assert.IsType(t, &UnknownItem{}, pl.Items[1])
+
+	tags := NewTagRegistry()
+	tags.RegisterTag("#EXT-X-CUE-OUT", decodeCueOut)
+
+	pl, err = ReadWithOptions(strings.NewReader(s), ReadOptions{Tags: tags})
+	require.NoError(t, err)
+	require.Len(t, pl.Items, 6)
+	assert.Equal(t, &cueOutItem{Duration: 30}, pl.Items[1])
+	assert.IsType(t, &UnknownItem{}, pl.Items[3])
+	require.Len(t, pl.Warnings(), 1)
+	assert.Equal(t, 11, pl.Warnings()[0].(*ParseError).Line)
+	assert.Equal(t, 3, pl.SegmentSize())
+
+	out, err := Write(pl)
+	require.NoError(t, err)
+	assert.Contains(t, out, "#EXT-X-CUE-OUT:30\n#EXTINF:10")
+
+	_, err = ReadWithOptions(strings.NewReader(s), ReadOptions{Tags: tags, Strict: true})
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, "#EXT-X-CUE-OUT", pe.Tag)
+
+	// tags implemented by this lib are not overridden
+	tags.RegisterTag(SegmentItemTag, func(line string) (Item, error) {
+		return nil, errors.New("unexpected")
+	})
+	pl, err = ReadWithOptions(strings.NewReader(s), ReadOptions{Tags: tags})
+	require.NoError(t, err)
+	assert.Equal(t, 3, pl.SegmentSize())
+}
+
+func TestRegisterTag(t *testing.T) {
+	RegisterTag("#EXT-X-TEST-GLOBAL", func(line string) (Item, error) {
+		return &cueOutItem{Duration: 1}, nil
+	})
+	defer delete(globalTags.decoders, "#EXT-X-TEST-GLOBAL")
+
+	s := "#EXTM3U\n#EXT-X-TEST-GLOBAL\n#EXTINF:4,\nsegment.ts\n"
+	pl, err := Read(strings.NewReader(s))
+	require.NoError(t, err)
+	assert.Equal(t, &cueOutItem{Duration: 1}, pl.Items[0])
+
+	// the reader registry takes precedence
+	tags := NewTagRegistry()
+	tags.RegisterTag("#EXT-X-TEST-GLOBAL", func(line string) (Item, error) {
+		return &cueOutItem{Duration: 2}, nil
+	})
+	pl, err = ReadWithOptions(strings.NewReader(s), ReadOptions{Tags: tags})
+	require.NoError(t, err)
+	assert.Equal(t, &cueOutItem{Duration: 2}, pl.Items[0])
+}
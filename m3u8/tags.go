@@ -9,22 +9,35 @@ import (
 const (
 	// Item tags
 
-	SessionKeyItemTag    = `#EXT-X-SESSION-KEY`
-	KeyItemTag           = `#EXT-X-KEY`
-	DiscontinuityItemTag = `#EXT-X-DISCONTINUITY`
-	TimeItemTag          = `#EXT-X-PROGRAM-DATE-TIME`
-	DateRangeItemTag     = `#EXT-X-DATERANGE`
-	MapItemTag           = `#EXT-X-MAP`
-	SessionDataItemTag   = `#EXT-X-SESSION-DATA`
-	SegmentItemTag       = `#EXTINF`
-	ByteRangeItemTag     = `#EXT-X-BYTERANGE`
-	PlaybackStartTag     = `#EXT-X-START`
-	MediaItemTag         = `#EXT-X-MEDIA`
-	PlaylistItemTag      = `#EXT-X-STREAM-INF`
-	PlaylistIframeTag    = `#EXT-X-I-FRAME-STREAM-INF`
-	DefineTag            = "#EXT-X-DEFINE"
-	SCTE35Tag            = "#EXT-X-SCTE35"
-	ImageStreamItemTag   = "#EXT-X-IMAGE-STREAM-INF"
+	SessionKeyItemTag      = `#EXT-X-SESSION-KEY`
+	KeyItemTag             = `#EXT-X-KEY`
+	DiscontinuityItemTag   = `#EXT-X-DISCONTINUITY`
+	TimeItemTag            = `#EXT-X-PROGRAM-DATE-TIME`
+	DateRangeItemTag       = `#EXT-X-DATERANGE`
+	MapItemTag             = `#EXT-X-MAP`
+	SessionDataItemTag     = `#EXT-X-SESSION-DATA`
+	SegmentItemTag         = `#EXTINF`
+	ByteRangeItemTag       = `#EXT-X-BYTERANGE`
+	PlaybackStartTag       = `#EXT-X-START`
+	MediaItemTag           = `#EXT-X-MEDIA`
+	PlaylistItemTag        = `#EXT-X-STREAM-INF`
+	PlaylistIframeTag      = `#EXT-X-I-FRAME-STREAM-INF`
+	DefineTag              = "#EXT-X-DEFINE"
+	SCTE35Tag              = "#EXT-X-SCTE35"
+	ImageStreamItemTag     = "#EXT-X-IMAGE-STREAM-INF"
+	PartItemTag            = "#EXT-X-PART"
+	SkipItemTag            = "#EXT-X-SKIP"
+	PreloadHintItemTag     = "#EXT-X-PRELOAD-HINT"
+	RenditionReportItemTag = "#EXT-X-RENDITION-REPORT"
+	GapItemTag             = "#EXT-X-GAP"
+	BitrateItemTag         = "#EXT-X-BITRATE"
+	ContentSteeringItemTag = "#EXT-X-CONTENT-STEERING"
+
+	// Cue tags (read as UnknownItem)
+
+	CueOutTag     = "#EXT-X-CUE-OUT"
+	CueOutContTag = "#EXT-X-CUE-OUT-CONT"
+	CueInTag      = "#EXT-X-CUE-IN"
 
 	// Playlist tags
 
@@ -38,6 +51,8 @@ const (
 	IFramesOnlyTag           = `#EXT-X-I-FRAMES-ONLY`
 	MediaSequenceTag         = `#EXT-X-MEDIA-SEQUENCE`
 	VersionTag               = `#EXT-X-VERSION`
+	PartInfTag               = `#EXT-X-PART-INF`
+	ServerControlTag         = `#EXT-X-SERVER-CONTROL`
 
 	// ByteRange tags
 
@@ -76,6 +91,43 @@ const (
 	CueInAttribute    = "CUE-IN"
 	SegneAttribute    = "SEGNE"
 
+	// PartItem tags
+
+	IndependentTag = "INDEPENDENT"
+	GapTag         = "GAP"
+
+	// PartInf tags
+
+	PartTargetTag = "PART-TARGET"
+
+	// ServerControl tags
+
+	CanSkipUntilTag      = "CAN-SKIP-UNTIL"
+	CanSkipDateRangesTag = "CAN-SKIP-DATERANGES"
+	HoldBackTag          = "HOLD-BACK"
+	PartHoldBackTag      = "PART-HOLD-BACK"
+	CanBlockReloadTag    = "CAN-BLOCK-RELOAD"
+
+	// SkipItem tags
+
+	SkippedSegmentsTag           = "SKIPPED-SEGMENTS"
+	RecentlyRemovedDateRangesTag = "RECENTLY-REMOVED-DATERANGES"
+
+	// PreloadHintItem tags
+
+	ByteRangeStartTag  = "BYTERANGE-START"
+	ByteRangeLengthTag = "BYTERANGE-LENGTH"
+
+	// RenditionReportItem tags
+
+	LastMSNTag  = "LAST-MSN"
+	LastPartTag = "LAST-PART"
+
+	// ContentSteeringItem tags
+
+	ServerURITag = "SERVER-URI"
+	PathwayIDTag = "PATHWAY-ID"
+
 	// PlaybackStart tags
 
 	TimeOffsetTag = "TIME-OFFSET"
@@ -122,6 +174,11 @@ const (
 	ClosedCaptionsTag   = "CLOSED-CAPTIONS"
 	HDCPLevelTag        = "HDCP-LEVEL"
 	StableVariantIDTag  = "STABLE-VARIANT-ID"
+
+	// Playlist types
+
+	PlaylistTypeVOD   = "VOD"
+	PlaylistTypeEvent = "EVENT"
 )
 
 var (
@@ -141,9 +198,17 @@ var tagsMap = map[string]struct {
 	Attributes []string
 	ReadLine   func(line string, pl *Playlist, st *state) error
 }{
-	"#EXT-X-BITRATE": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	BitrateItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewBitrateItem(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			st.master = false
+			pl.Items = append(pl.Items, item)
+			return nil
+		},
+	},
 	ByteRangeItemTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
 			value := strings.Replace(line, ByteRangeItemTag+":", "", -1)
@@ -167,9 +232,13 @@ var tagsMap = map[string]struct {
 			return nil
 		},
 	},
-	"#EXT-X-CONTENT-STEERING": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	ContentSteeringItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item := NewContentSteeringItem(line)
+			pl.Items = append(pl.Items, item)
+			return nil
+		},
+	},
 	DateRangeItemTag: {
 		Attributes: []string{
 			IDTag,
@@ -224,9 +293,21 @@ var tagsMap = map[string]struct {
 			return nil
 		},
 	},
-	"#EXT-X-GAP": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	GapItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			st.master = false
+			if st.open {
+				item, ok := st.currentItem.(*SegmentItem)
+				if !ok {
+					return parseError(line, ErrSegmentItemInvalid)
+				}
+				item.Gap = true
+			} else {
+				st.gap = true
+			}
+			return nil
+		},
+	},
 	PlaylistIframeTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
 			st.master = true
@@ -279,21 +360,43 @@ var tagsMap = map[string]struct {
 			return err
 		},
 	},
-	"#EXT-X-PART": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
-	"#EXT-X-PART-INF": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	PartItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewPartItem(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			st.master = false
+			st.appendPart(pl, item)
+			return nil
+		},
+	},
+	PartInfTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewPartInf(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			pl.PartInf = item
+			return nil
+		},
+	},
 	PlaylistTypeTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
 			pl.Type = parseStringPtr(line, PlaylistTypeTag)
 			return nil
 		},
 	},
-	"#EXT-X-PRELOAD-HINT": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	PreloadHintItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewPreloadHintItem(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			pl.Items = append(pl.Items, item)
+			return nil
+		},
+	},
 	TimeItemTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
 			pdt, err := NewTimeItem(line)
@@ -312,12 +415,26 @@ var tagsMap = map[string]struct {
 			return nil
 		},
 	},
-	"#EXT-X-RENDITION-REPORT": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
-	"#EXT-X-SERVER-CONTROL": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	RenditionReportItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewRenditionReportItem(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			pl.Items = append(pl.Items, item)
+			return nil
+		},
+	},
+	ServerControlTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewServerControl(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			pl.ServerControl = item
+			return nil
+		},
+	},
 	SessionDataItemTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
 			sdi := NewSessionDataItem(line)
@@ -332,9 +449,17 @@ var tagsMap = map[string]struct {
 			return nil
 		},
 	},
-	"#EXT-X-SKIP": {
-		ReadLine: notImplementedReadLine,
-	}, // TODO
+	SkipItemTag: {
+		ReadLine: func(line string, pl *Playlist, st *state) error {
+			item, err := NewSkipItem(line)
+			if err != nil {
+				return parseError(line, err)
+			}
+			st.master = false
+			pl.Items = append(pl.Items, item)
+			return nil
+		},
+	},
 	PlaybackStartTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
 			ps, err := NewPlaybackStart(line)
@@ -378,12 +503,18 @@ var tagsMap = map[string]struct {
 	},
 	SegmentItemTag: {
 		ReadLine: func(line string, pl *Playlist, st *state) error {
-			var err error
-			st.currentItem, err = NewSegmentItem(line)
+			si, err := NewSegmentItem(line)
+			if err != nil {
+				return err
+			}
+			st.attachParts(pl, si)
+			si.Gap = st.gap
+			st.gap = false
+			st.currentItem = si
 			st.master = false
 			st.open = true
 
-			return err
+			return nil
 
 		},
 	},
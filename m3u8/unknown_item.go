@@ -30,3 +30,8 @@ func (i *UnknownItem) GetTagName() string {
 	name := parser.ParseTagName(i.tagValue)
 	return name
 }
+
+// Err returns the error of parsing a known tag, nil for unknown tags
+func (i *UnknownItem) Err() error {
+	return i.err
+}
@@ -0,0 +1,211 @@
+package m3u8
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
+)
+
+var (
+	variableNameRegex      = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	variableReferenceRegex = regexp.MustCompile(`\{\$([A-Za-z0-9_-]+)\}`)
+)
+
+// Variables returns the variables defined by EXT-X-DEFINE tags of a playlist.
+// imported holds the variables of the parent master playlist (as returned by its Variables),
+// requestURL is the URL the playlist was requested with, used by QUERYPARAM definitions.
+func (pl *Playlist) Variables(imported map[string]string, requestURL *url.URL) (map[string]string, error) {
+	variables := make(map[string]string)
+
+	for _, item := range pl.Items {
+		di, ok := item.(*DefineItem)
+		if !ok {
+			continue
+		}
+
+		var (
+			name  string
+			value string
+		)
+		switch {
+		case di.Value != nil:
+			name, value = di.Name, *di.Value
+		case di.Import != nil:
+			name = *di.Import
+			if pl.IsMaster() {
+				return nil, fmt.Errorf("%w: %s is imported in master playlist", ErrVariableInvalid, name)
+			}
+			v, ok := imported[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrVariableUndefined, name)
+			}
+			value = v
+		case di.QueryParam != nil:
+			name = *di.QueryParam
+			if requestURL == nil {
+				return nil, fmt.Errorf("%w: %s", ErrVariableUndefined, name)
+			}
+			values, ok := requestURL.Query()[name]
+			if !ok || len(values) == 0 {
+				return nil, fmt.Errorf("%w: %s", ErrVariableUndefined, name)
+			}
+			value = values[0]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrVariableInvalid, di.String())
+		}
+
+		if !variableNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("%w: %s", ErrVariableInvalid, di.String())
+		}
+		if _, ok := variables[name]; ok {
+			return nil, fmt.Errorf("%w: %s is defined more than once", ErrVariableInvalid, name)
+		}
+		variables[name] = value
+	}
+
+	return variables, nil
+}
+
+// ResolveVariables substitutes the variable references ({$name}) in the URIs and the quoted-string attributes
+// of the playlist items with the values of the variables defined in the playlist (see Variables),
+// a reference to an undefined variable is an error (the items preceding it stay resolved)
+func (pl *Playlist) ResolveVariables(imported map[string]string, requestURL *url.URL) error {
+	variables, err := pl.Variables(imported, requestURL)
+	if err != nil {
+		return err
+	}
+
+	r := &variableResolver{variables: variables}
+	for _, item := range pl.Items {
+		switch it := item.(type) {
+		case *SegmentItem:
+			r.resolve(&it.Segment)
+			for _, part := range it.Parts {
+				r.resolvePart(part)
+			}
+		case *PlaylistItem:
+			r.resolve(&it.URI, it.Name, it.Codecs, it.Video, it.Audio, it.Subtitles, it.ClosedCaptions,
+				it.StableVariantID, it.PathwayID)
+			r.resolveAttributes(it.attributes)
+		case *ImageStreamItem:
+			r.resolve(&it.URI, it.Name, it.Codecs, it.Video, it.Audio, it.Subtitles, it.ClosedCaptions,
+				it.StableVariantID)
+			r.resolveAttributes(it.attributes)
+		case *MediaItem:
+			r.resolve(&it.GroupID, &it.Name, it.Language, it.AssocLanguage, it.URI, it.InStreamID,
+				it.Characteristics, it.Channels, it.StableRenditionId, it.PathwayID)
+			r.resolveAttributes(it.attributes)
+		case *MapItem:
+			r.resolve(&it.URI)
+			r.resolveAttributes(it.attributes)
+		case *KeyItem:
+			r.resolveEncryptable(it.Encryptable)
+		case *SessionKeyItem:
+			r.resolveEncryptable(it.Encryptable)
+		case *SessionDataItem:
+			r.resolve(&it.DataID, it.Value, it.URI, it.Language)
+			r.resolveAttributes(it.attributes)
+		case *PartItem:
+			r.resolvePart(it)
+		case *PreloadHintItem:
+			r.resolve(&it.URI)
+			r.resolveAttributes(it.attributes)
+		case *RenditionReportItem:
+			r.resolve(&it.URI)
+			r.resolveAttributes(it.attributes)
+		case *DateRangeItem:
+			r.resolve(&it.ID, it.Class, it.Cue, &it.StartDate, it.EndDate)
+			for _, a := range sortedAttributes(it.ClientAttributes) {
+				if isQuoted(a.Value) {
+					r.resolve(&a.Value)
+					it.ClientAttributes[a.Name] = a.Value
+				}
+			}
+		case *ContentSteeringItem:
+			r.resolve(&it.ServerURI, it.PathwayID)
+			r.resolveAttributes(it.attributes)
+		case *SCTE35Item:
+			r.resolve(&it.Cue, it.ID, it.UPID, it.Segne)
+			r.resolveAttributes(it.attributes)
+		case *SkipItem:
+			for i := range it.RecentlyRemovedDateRanges {
+				r.resolve(&it.RecentlyRemovedDateRanges[i])
+			}
+			r.resolveAttributes(it.attributes)
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+
+	return nil
+}
+
+// variableResolver substitutes variable references, keeping the first error
+type variableResolver struct {
+	variables map[string]string
+	err       error
+}
+
+// resolve substitutes the variable references of strings, nil ones are skipped
+func (r *variableResolver) resolve(values ...*string) {
+	for _, s := range values {
+		if s == nil || r.err != nil {
+			continue
+		}
+		value, err := substituteVariables(*s, r.variables)
+		if err != nil {
+			r.err = err
+			return
+		}
+		*s = value
+	}
+}
+
+// resolveAttributes substitutes the variable references of the quoted-string values of unknown attributes
+func (r *variableResolver) resolveAttributes(attributes parser.AttributeList) {
+	for i := range attributes {
+		if isQuoted(attributes[i].Value) {
+			r.resolve(&attributes[i].Value)
+		}
+	}
+}
+
+func (r *variableResolver) resolveEncryptable(e *Encryptable) {
+	if e == nil {
+		return
+	}
+	r.resolve(e.URI, e.KeyFormat, e.KeyFormatVersions)
+	r.resolveAttributes(e.attributes)
+}
+
+func (r *variableResolver) resolvePart(pi *PartItem) {
+	r.resolve(&pi.URI)
+	r.resolveAttributes(pi.attributes)
+}
+
+// isQuoted reports if an attribute value in its original state is a quoted-string
+func isQuoted(value string) bool {
+	return len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
+}
+
+func substituteVariables(s string, variables map[string]string) (string, error) {
+	var err error
+
+	result := variableReferenceRegex.ReplaceAllStringFunc(s, func(reference string) string {
+		name := variableReferenceRegex.FindStringSubmatch(reference)[1]
+		value, ok := variables[name]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("%w: %s", ErrVariableUndefined, name)
+			}
+			return reference
+		}
+		return value
+	})
+
+	return result, err
+}
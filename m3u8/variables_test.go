@@ -0,0 +1,178 @@
+package m3u8
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const variablesMasterPlaylist = `#EXTM3U
+#EXT-X-DEFINE:NAME="host",VALUE="https://cdn.example.com"
+#EXT-X-DEFINE:QUERYPARAM="token"
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",NAME="English",URI="{$host}/audio.m3u8?token={$token}"
+#EXT-X-STREAM-INF:BANDWIDTH=1280000,AUDIO="aac"
+{$host}/low.m3u8?token={$token}
+`
+
+const variablesMediaPlaylist = `#EXTM3U
+#EXT-X-TARGETDURATION:4
+#EXT-X-DEFINE:IMPORT="host"
+#EXT-X-DEFINE:NAME="path",VALUE="/video/1"
+#EXT-X-KEY:METHOD=AES-128,URI="{$host}/key?id=1"
+#EXT-X-MAP:URI="{$host}{$path}/init.mp4"
+#EXTINF:4,
+{$host}{$path}/segment1.mp4
+#EXTINF:4,
+segment2.mp4
+`
+
+func TestPlaylist_ResolveVariables(t *testing.T) {
+	master, err := ReadString(variablesMasterPlaylist)
+	require.NoError(t, err)
+	requestURL, err := url.Parse("https://origin.example.com/master.m3u8?token=abc")
+	require.NoError(t, err)
+
+	variables, err := master.Variables(nil, requestURL)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"host": "https://cdn.example.com", "token": "abc"}, variables)
+
+	require.NoError(t, master.ResolveVariables(nil, requestURL))
+	assertNotNilEqual(t, "https://cdn.example.com/audio.m3u8?token=abc", master.Items[2].(*MediaItem).URI)
+	assert.Equal(t, "https://cdn.example.com/low.m3u8?token=abc", master.Playlists()[0].URI)
+
+	media, err := ReadString(variablesMediaPlaylist)
+	require.NoError(t, err)
+	require.NoError(t, media.ResolveVariables(variables, nil))
+	assertNotNilEqual(t, "https://cdn.example.com/key?id=1", media.Items[2].(*KeyItem).Encryptable.URI)
+	assert.Equal(t, "https://cdn.example.com/video/1/init.mp4", media.Items[3].(*MapItem).URI)
+	assert.Equal(t, "https://cdn.example.com/video/1/segment1.mp4", media.Segments()[0].Segment)
+	assert.Equal(t, "segment2.mp4", media.Segments()[1].Segment)
+}
+
+func TestPlaylist_ResolveVariables_Invalid(t *testing.T) {
+	master, err := ReadString(variablesMasterPlaylist)
+	require.NoError(t, err)
+
+	// QUERYPARAM is missing in the request URL
+	requestURL, err := url.Parse("https://origin.example.com/master.m3u8")
+	require.NoError(t, err)
+	err = master.ResolveVariables(nil, requestURL)
+	assert.True(t, errors.Is(err, ErrVariableUndefined))
+	assert.Equal(t, "{$host}/low.m3u8?token={$token}", master.Playlists()[0].URI)
+
+	// imported variable is not defined in master playlist
+	media, err := ReadString(variablesMediaPlaylist)
+	require.NoError(t, err)
+	err = media.ResolveVariables(map[string]string{"other": "1"}, nil)
+	assert.True(t, errors.Is(err, ErrVariableUndefined))
+
+	testCases := []string{
+		// undefined reference
+		"#EXTM3U\n#EXT-X-DEFINE:NAME=\"a\",VALUE=\"1\"\n#EXTINF:4,\n{$b}.ts\n",
+		// defined twice
+		"#EXTM3U\n#EXT-X-DEFINE:NAME=\"a\",VALUE=\"1\"\n#EXT-X-DEFINE:NAME=\"a\",VALUE=\"2\"\n#EXTINF:4,\n{$a}.ts\n",
+		// invalid name
+		"#EXTM3U\n#EXT-X-DEFINE:NAME=\"a b\",VALUE=\"1\"\n#EXTINF:4,\n{$a}.ts\n",
+		// import in master playlist
+		"#EXTM3U\n#EXT-X-DEFINE:IMPORT=\"a\"\n#EXT-X-STREAM-INF:BANDWIDTH=1\n{$a}.m3u8\n",
+	}
+	expected := []error{ErrVariableUndefined, ErrVariableInvalid, ErrVariableInvalid, ErrVariableInvalid}
+
+	for i, tc := range testCases {
+		pl, err := ReadString(tc)
+		require.NoError(t, err)
+		err = pl.ResolveVariables(map[string]string{"a": "1"}, nil)
+		assert.True(t, errors.Is(err, expected[i]), tc)
+	}
+}
+
+func TestPlaylist_ResolveVariables_QuotedStrings(t *testing.T) {
+	master, err := ReadString(`#EXTM3U
+#EXT-X-DEFINE:NAME="lang",VALUE="en"
+#EXT-X-DEFINE:NAME="cdn",VALUE="CDN-A"
+#EXT-X-DEFINE:NAME="group",VALUE="aac"
+#EXT-X-CONTENT-STEERING:SERVER-URI="https://steering.example.com/{$lang}.json",PATHWAY-ID="{$cdn}"
+#EXT-X-SESSION-DATA:DATA-ID="com.example.{$lang}",VALUE="{$lang}",LANGUAGE="{$lang}"
+#EXT-X-SESSION-KEY:METHOD=AES-128,URI="key",KEYFORMAT="com.example.{$lang}",KEYFORMATVERSIONS="1"
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="{$group}",NAME="{$lang}",LANGUAGE="{$lang}",ASSOC-LANGUAGE="{$lang}-US",CHARACTERISTICS="public.{$lang}",CHANNELS="2",STABLE-RENDITION-ID="{$lang}",PATHWAY-ID="{$cdn}",X-CUSTOM="{$lang}",URI="{$lang}.m3u8"
+#EXT-X-STREAM-INF:BANDWIDTH=1280000,AUDIO="{$group}",VIDEO="{$group}-video",SUBTITLES="{$group}-subs",CLOSED-CAPTIONS="{$group}-cc",NAME="{$lang}",STABLE-VARIANT-ID="{$lang}-low",PATHWAY-ID="{$cdn}",X-CUSTOM="{$lang}",X-UNQUOTED={$lang}
+low.m3u8
+#EXT-X-IMAGE-STREAM-INF:BANDWIDTH=1000,URI="{$lang}-images.m3u8",NAME="{$lang}"
+`)
+	require.NoError(t, err)
+	require.NoError(t, master.ResolveVariables(nil, nil))
+
+	out, err := Write(master)
+	require.NoError(t, err)
+	assert.NotContains(t, out, `"{$`)
+	assert.Contains(t, out, "X-UNQUOTED={$lang}")
+
+	csi := master.Items[3].(*ContentSteeringItem)
+	assert.Equal(t, "https://steering.example.com/en.json", csi.ServerURI)
+	assertNotNilEqual(t, "CDN-A", csi.PathwayID)
+
+	sdi := master.Items[4].(*SessionDataItem)
+	assert.Equal(t, "com.example.en", sdi.DataID)
+	assertNotNilEqual(t, "en", sdi.Language)
+
+	assertNotNilEqual(t, "com.example.en", master.Items[5].(*SessionKeyItem).Encryptable.KeyFormat)
+
+	mi := master.Items[6].(*MediaItem)
+	assert.Equal(t, "aac", mi.GroupID)
+	assert.Equal(t, "en", mi.Name)
+	assertNotNilEqual(t, "en", mi.Language)
+	assertNotNilEqual(t, "en-US", mi.AssocLanguage)
+	assertNotNilEqual(t, "public.en", mi.Characteristics)
+	assertNotNilEqual(t, "en", mi.StableRenditionId)
+	assertNotNilEqual(t, "CDN-A", mi.PathwayID)
+	assertNotNilEqual(t, "en.m3u8", mi.URI)
+	assert.Contains(t, mi.String(), `X-CUSTOM="en"`)
+
+	pi := master.Playlists()[0]
+	assertNotNilEqual(t, "aac", pi.Audio)
+	assertNotNilEqual(t, "aac-video", pi.Video)
+	assertNotNilEqual(t, "aac-subs", pi.Subtitles)
+	assertNotNilEqual(t, "aac-cc", pi.ClosedCaptions)
+	assertNotNilEqual(t, "en", pi.Name)
+	assertNotNilEqual(t, "en-low", pi.StableVariantID)
+	assertNotNilEqual(t, "CDN-A", pi.PathwayID)
+	assert.Contains(t, pi.String(), `X-CUSTOM="en"`)
+
+	isi := master.Items[8].(*ImageStreamItem)
+	assert.Equal(t, "en-images.m3u8", isi.URI)
+	assertNotNilEqual(t, "en", isi.Name)
+
+	media, err := ReadString(`#EXTM3U
+#EXT-X-TARGETDURATION:4
+#EXT-X-DEFINE:NAME="id",VALUE="ad-1"
+#EXT-X-DATERANGE:ID="{$id}",CLASS="com.example.{$id}",START-DATE="2020-01-01T00:00:00Z",X-AD-ID="{$id}",X-COUNT=1
+#EXT-X-SCTE35:CUE="/DA",ID="{$id}",UPID="0x0C:0x{$id}"
+#EXTINF:4,
+segment1.mp4
+`)
+	require.NoError(t, err)
+	require.NoError(t, media.ResolveVariables(nil, nil))
+
+	dri := media.Items[1].(*DateRangeItem)
+	assert.Equal(t, "ad-1", dri.ID)
+	assertNotNilEqual(t, "com.example.ad-1", dri.Class)
+	assert.Equal(t, `"ad-1"`, dri.ClientAttributes["X-AD-ID"])
+	assert.Equal(t, "1", dri.ClientAttributes["X-COUNT"])
+
+	si := media.Items[2].(*SCTE35Item)
+	assertNotNilEqual(t, "ad-1", si.ID)
+	assertNotNilEqual(t, "0x0C:0xad-1", si.UPID)
+
+	// an undefined variable in a quoted-string attribute is an error
+	media, err = ReadString(`#EXTM3U
+#EXT-X-TARGETDURATION:4
+#EXT-X-DATERANGE:ID="ad",START-DATE="2020-01-01T00:00:00Z",X-AD-ID="{$id}"
+#EXTINF:4,
+segment1.mp4
+`)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(media.ResolveVariables(nil, nil), ErrVariableUndefined))
+}
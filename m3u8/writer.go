@@ -7,13 +7,18 @@ import (
 	"github.com/NBCUDTC/midnight-hls-go-parser-src/m3u8/parser"
 )
 
-// Write writes a playlist to a string
+// Write writes a playlist to a string.
+// A playlist read in lossless mode (see ReadOptions) is written with the source text of its unchanged items.
 func Write(pl *Playlist) (string, error) {
 	var sb strings.Builder
 
 	if !pl.IsValid() {
 		return "", ErrPlaylistInvalidType
 	}
+	if pl.source != nil {
+		pl.source.write(&sb, pl)
+		return sb.String(), nil
+	}
 	writeHeader(&sb, pl)
 	for _, item := range pl.Items {
 		sb.WriteString(item.String())
@@ -28,70 +33,70 @@ func writeHeader(sb *strings.Builder, pl *Playlist) {
 	sb.WriteString(HeaderTag)
 	sb.WriteRune('\n')
 
-	if pl.IsMaster() {
-		writeVersionTag(sb, pl.Version)
-		writeIndependentSegmentsTag(sb, pl.IndependentSegments)
-	} else {
-		if pl.Type != nil {
-			sb.WriteString(fmt.Sprintf("%s:%s", PlaylistTypeTag, *pl.Type))
-			sb.WriteRune('\n')
-		}
-		writeVersionTag(sb, pl.Version)
-		writeIndependentSegmentsTag(sb, pl.IndependentSegments)
-		if pl.IFramesOnly {
-			sb.WriteString(IFramesOnlyTag)
-			sb.WriteRune('\n')
-		}
-		sb.WriteString(fmt.Sprintf("%s:%v", MediaSequenceTag, pl.Sequence))
-		sb.WriteRune('\n')
-		writeDiscontinuitySequenceTag(sb, pl.DiscontinuitySequence)
-		writeCacheTag(sb, pl.Cache)
-		sb.WriteString(fmt.Sprintf("%s:%v", TargetDurationTag, pl.Target))
+	for _, line := range headerLines(pl) {
+		sb.WriteString(line.text)
 		sb.WriteRune('\n')
 	}
 }
 
-func writeFooter(sb *strings.Builder, pl *Playlist) {
-	if pl.IsLive() || pl.IsMaster() {
-		return
-	}
-
-	sb.WriteString(FooterTag)
-	sb.WriteRune('\n')
+// headerLine represents a playlist tag written in the header
+type headerLine struct {
+	tag  string
+	text string
 }
 
-func writeVersionTag(sb *strings.Builder, version *int) {
-	if version == nil {
-		return
+// headerLines returns the playlist tags of the header in the order they are written
+func headerLines(pl *Playlist) []headerLine {
+	var lines []headerLine
+	add := func(tag, text string) {
+		lines = append(lines, headerLine{tag: tag, text: text})
 	}
 
-	sb.WriteString(fmt.Sprintf("%s:%v", VersionTag, *version))
-	sb.WriteRune('\n')
-}
-
-func writeIndependentSegmentsTag(sb *strings.Builder, toWrite bool) {
-	if !toWrite {
-		return
+	if pl.IsMaster() {
+		if pl.Version != nil {
+			add(VersionTag, fmt.Sprintf("%s:%v", VersionTag, *pl.Version))
+		}
+		if pl.IndependentSegments {
+			add(IndependentSegmentsTag, IndependentSegmentsTag)
+		}
+		return lines
 	}
 
-	sb.WriteString(IndependentSegmentsTag)
-	sb.WriteRune('\n')
-}
-
-func writeDiscontinuitySequenceTag(sb *strings.Builder, sequence *int) {
-	if sequence == nil {
-		return
+	if pl.Type != nil {
+		add(PlaylistTypeTag, fmt.Sprintf("%s:%s", PlaylistTypeTag, *pl.Type))
+	}
+	if pl.Version != nil {
+		add(VersionTag, fmt.Sprintf("%s:%v", VersionTag, *pl.Version))
+	}
+	if pl.IndependentSegments {
+		add(IndependentSegmentsTag, IndependentSegmentsTag)
+	}
+	if pl.IFramesOnly {
+		add(IFramesOnlyTag, IFramesOnlyTag)
+	}
+	add(MediaSequenceTag, fmt.Sprintf("%s:%v", MediaSequenceTag, pl.Sequence))
+	if pl.DiscontinuitySequence != nil {
+		add(DiscontinuitySequenceTag, fmt.Sprintf("%s:%v", DiscontinuitySequenceTag, *pl.DiscontinuitySequence))
+	}
+	if pl.Cache != nil {
+		add(CacheTag, fmt.Sprintf("%s:%s", CacheTag, parser.FormatYesNo(*pl.Cache)))
+	}
+	add(TargetDurationTag, fmt.Sprintf("%s:%v", TargetDurationTag, pl.Target))
+	if pl.ServerControl != nil {
+		add(ServerControlTag, pl.ServerControl.String())
+	}
+	if pl.PartInf != nil {
+		add(PartInfTag, pl.PartInf.String())
 	}
 
-	sb.WriteString(fmt.Sprintf("%s:%v", DiscontinuitySequenceTag, *sequence))
-	sb.WriteRune('\n')
+	return lines
 }
 
-func writeCacheTag(sb *strings.Builder, cache *bool) {
-	if cache == nil {
+func writeFooter(sb *strings.Builder, pl *Playlist) {
+	if pl.IsLive() || pl.IsMaster() {
 		return
 	}
 
-	sb.WriteString(fmt.Sprintf("%s:%s", CacheTag, parser.FormatYesNo(*cache)))
+	sb.WriteString(FooterTag)
 	sb.WriteRune('\n')
 }